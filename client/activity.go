@@ -1,5 +1,7 @@
 package client
 
+import "context"
+
 // Activity represents Redmine activity for time that's being tracked.
 type Activity struct {
 	ID   int64  `json:"id"`
@@ -15,8 +17,8 @@ type activitiesResponse struct {
 
 // GetActivities fetches all Activities that can be entered in time entry record. Project specific
 // activities cannot be fetched with this method.
-func (c *Client) GetActivities() (Activities, error) {
-	req, err := c.getRequest("/enumerations/time_entry_activities.json", "")
+func (c *Client) GetActivities(ctx context.Context) (Activities, error) {
+	req, err := c.getRequest(ctx, "/enumerations/time_entry_activities.json", "")
 	if err != nil {
 		return nil, err
 	}
@@ -1,97 +1,219 @@
+// Package client is a client of Redmine REST API.
+//
+//	c, err := client.New(
+//		client.WithBaseURL("https://host.redmine.org"),
+//		client.WithAPIKey(apiKey),
+//	)
+//	if err != nil {
+//		return err
+//	}
+//	issue, err := c.GetIssue(ctx, 20123)
 package client
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"github.com/mightymatth/arcli/config"
 	"io"
-	"io/ioutil"
-	"log"
 	"net/http"
 	"net/url"
 	"os"
-
-	"github.com/spf13/viper"
+	"time"
 )
 
+// ErrMissingCredentials is returned when request is made by client without
+// base URL or API key.
+var ErrMissingCredentials = errors.New("missing Redmine base URL or API key")
+
 // Client is main HTTP client for communication with Redmine server.
 type Client struct {
+	// BaseURL is URL of Redmine server (e.g. https://host.redmine.org).
+	BaseURL string
+	// APIKey is key of user on whose behalf requests are made.
+	APIKey     string
 	HTTPClient *http.Client
 	UserAgent  string
+	// PageConcurrency limits the number of pages fetched in parallel
+	// (DefaultPageConcurrency is used if not set).
+	PageConcurrency int
+
+	// timeout is set by WithTimeout, after all options are applied.
+	timeout *time.Duration
 }
 
-func (c *Client) getRequest(path string, queryParams string) (*http.Request, error) {
-	host, apiKey := getCredentials()
-	c.setTransport()
+// Option configures Client created by New.
+type Option func(c *Client) error
 
-	u, err := url.Parse(host)
-	if err != nil {
-		return nil, err
+// New creates client configured with given options.
+func New(opts ...Option) (*Client, error) {
+	c := &Client{
+		HTTPClient: &http.Client{},
+		UserAgent:  "arcli",
 	}
 
-	u.Path = path
-	u.RawQuery = queryParams
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
+	}
+	if c.timeout != nil {
+		c.HTTPClient.Timeout = *c.timeout
+	}
 
-	var buf io.ReadWriter
-	req, err := http.NewRequest("GET", u.String(), buf)
-	if err != nil {
-		return nil, err
+	return c, nil
+}
+
+// WithBaseURL sets URL of Redmine server.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) error {
+		u, err := url.Parse(baseURL)
+		if err != nil {
+			return fmt.Errorf("invalid base URL: %w", err)
+		}
+		if u.Scheme != "" && u.Scheme != "http" && u.Scheme != "https" {
+			return fmt.Errorf("invalid base URL scheme '%v'", u.Scheme)
+		}
+
+		c.BaseURL = baseURL
+		return nil
 	}
+}
 
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("User-Agent", c.UserAgent)
-	req.Header.Set("X-Redmine-API-Key", apiKey)
+// WithAPIKey sets key used to authenticate requests.
+func WithAPIKey(apiKey string) Option {
+	return func(c *Client) error {
+		c.APIKey = apiKey
+		return nil
+	}
+}
 
-	return req, nil
+// WithHTTPClient sets HTTP client used to make requests. The client is copied,
+// so other options do not change the given one.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) error {
+		copied := *httpClient
+		c.HTTPClient = &copied
+		return nil
+	}
 }
 
-func (c *Client) postRequest(path string, body interface{}) (*http.Request, error) {
-	host, apiKey := getCredentials()
-	c.setTransport()
+// WithTimeout sets time limit of requests, including reading of response body,
+// regardless of the order of options. Zero means no limit.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) error {
+		c.timeout = &timeout
+		return nil
+	}
+}
 
-	u, err := url.Parse(host)
-	if err != nil {
-		return nil, err
+// WithUserAgent sets User-Agent header of requests.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) error {
+		c.UserAgent = userAgent
+		return nil
 	}
+}
 
-	u.Path = path
+// WithTLSConfig sets TLS configuration of HTTP client transport.
+func WithTLSConfig(tlsConfig *tls.Config) Option {
+	return func(c *Client) error {
+		transport, ok := c.HTTPClient.Transport.(*http.Transport)
+		switch {
+		case c.HTTPClient.Transport == nil:
+			transport = http.DefaultTransport.(*http.Transport).Clone()
+		case !ok:
+			return errors.New("TLS configuration cannot be set on custom HTTP transport")
+		default:
+			transport = transport.Clone()
+		}
 
-	var buf io.ReadWriter
-	if body != nil {
-		buf = new(bytes.Buffer)
-		err := json.NewEncoder(buf).Encode(body)
+		transport.TLSClientConfig = tlsConfig
+		c.HTTPClient.Transport = transport
+		return nil
+	}
+}
+
+// WithCACert makes client trust server certificates signed by CA certificate
+// in given PEM file, in addition to system ones. Empty file name is ignored.
+func WithCACert(file string) Option {
+	return func(c *Client) error {
+		if file == "" {
+			return nil
+		}
+
+		certPool, err := x509.SystemCertPool()
 		if err != nil {
-			return nil, err
+			return fmt.Errorf("cannot get system cert pool: %w", err)
+		}
+
+		cert, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("cannot read CA certificate: %w", err)
 		}
+
+		if !certPool.AppendCertsFromPEM(cert) {
+			return fmt.Errorf("no certificates found in %v", file)
+		}
+
+		return WithTLSConfig(&tls.Config{RootCAs: certPool})(c)
 	}
+}
 
-	req, err := http.NewRequest("POST", u.String(), buf)
+// IssueURL returns URL of issue in Redmine web interface.
+func (c *Client) IssueURL(id int64) string {
+	return c.pageURL(fmt.Sprintf("/issues/%v", id))
+}
+
+// ProjectURL returns URL of project in Redmine web interface.
+func (c *Client) ProjectURL(id int64) string {
+	return c.pageURL(fmt.Sprintf("/projects/%v", id))
+}
+
+func (c *Client) pageURL(path string) string {
+	u, err := url.Parse(c.BaseURL)
 	if err != nil {
-		return nil, err
-	}
-	if body != nil {
-		req.Header.Set("Content-Type", "application/json")
+		return ""
 	}
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("User-Agent", c.UserAgent)
-	req.Header.Set("X-Redmine-API-Key", apiKey)
 
-	return req, nil
+	u.Path = path
+
+	return u.String()
+}
+
+func (c *Client) getRequest(ctx context.Context, path string, queryParams string) (*http.Request, error) {
+	return c.newRequest(ctx, "GET", path, queryParams, nil)
+}
+
+func (c *Client) postRequest(ctx context.Context, path string, body interface{}) (*http.Request, error) {
+	return c.newRequest(ctx, "POST", path, "", body)
+}
+
+func (c *Client) putRequest(ctx context.Context, path string, body interface{}) (*http.Request, error) {
+	return c.newRequest(ctx, "PUT", path, "", body)
+}
+
+func (c *Client) deleteRequest(ctx context.Context, path string) (*http.Request, error) {
+	return c.newRequest(ctx, "DELETE", path, "", nil)
 }
 
-func (c *Client) putRequest(path string, body interface{}) (*http.Request, error) {
-	host, apiKey := getCredentials()
-	c.setTransport()
+// newRequest creates request authenticated with API key, with body (if not nil)
+// encoded as JSON.
+func (c *Client) newRequest(ctx context.Context, method, path, queryParams string, body interface{}) (*http.Request, error) {
+	if c.BaseURL == "" || c.APIKey == "" {
+		return nil, ErrMissingCredentials
+	}
 
-	u, err := url.Parse(host)
+	u, err := url.Parse(c.BaseURL)
 	if err != nil {
 		return nil, err
 	}
 
 	u.Path = path
+	u.RawQuery = queryParams
 
 	var buf io.ReadWriter
 	if body != nil {
@@ -102,7 +224,7 @@ func (c *Client) putRequest(path string, body interface{}) (*http.Request, error
 		}
 	}
 
-	req, err := http.NewRequest("PUT", u.String(), buf)
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), buf)
 	if err != nil {
 		return nil, err
 	}
@@ -111,36 +233,13 @@ func (c *Client) putRequest(path string, body interface{}) (*http.Request, error
 	}
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("User-Agent", c.UserAgent)
-	req.Header.Set("X-Redmine-API-Key", apiKey)
+	req.Header.Set("X-Redmine-API-Key", c.APIKey)
 
 	return req, nil
 }
 
-func (c *Client) deleteRequest(path string) (*http.Request, error) {
-	host, apiKey := getCredentials()
-	c.setTransport()
-
-	u, err := url.Parse(host)
-	if err != nil {
-		return nil, err
-	}
-
-	u.Path = path
-
-	var buf io.ReadWriter
-	req, err := http.NewRequest("DELETE", u.String(), buf)
-	if err != nil {
-		return nil, err
-	}
-
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("User-Agent", c.UserAgent)
-	req.Header.Set("X-Redmine-API-Key", apiKey)
-
-	return req, nil
-}
-
-// Do does the same as http.Client.Do() but also set response to provided v value.
+// Do does the same as http.Client.Do() but also decodes response body to provided
+// v value (if not nil). Responses with error status code are returned as *APIError.
 func (c *Client) Do(req *http.Request, v interface{}) (*http.Response, error) {
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
@@ -148,46 +247,20 @@ func (c *Client) Do(req *http.Request, v interface{}) (*http.Response, error) {
 	}
 	defer resp.Body.Close()
 
-	err = json.NewDecoder(resp.Body).Decode(v)
-	if err != nil {
-		return resp, err
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return resp, newAPIError(resp)
 	}
 
-	return resp, nil
-}
-
-func getCredentials() (host, apiKey string) {
-	host = viper.GetString(config.Host)
-	apiKey = viper.GetString(config.APIKey)
-
-	if host == "" || apiKey == "" {
-		fmt.Println("You are not logged in.")
-		os.Exit(1)
+	if v == nil || resp.StatusCode == http.StatusNoContent {
+		return resp, nil
 	}
 
-	return
-}
-
-func getTransport() *http.Transport {
-	caCert := viper.GetString(config.CaCert)
-
-	if caCert == "" {
-		return nil
-	}
-
-	certPool, err := x509.SystemCertPool()
-	if err != nil {
-		log.Fatalln("Unable to get system cert pool:", err)
-	}
-
-	cert, err := ioutil.ReadFile(caCert)
-	if err != nil {
-		log.Fatalln("Cannot fetch ssl certificate:", err)
+	err = json.NewDecoder(resp.Body).Decode(v)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return resp, fmt.Errorf("cannot decode response of %v %v: %w", req.Method, req.URL.Path, err)
 	}
 
-	certPool.AppendCertsFromPEM(cert)
-
-	return &http.Transport{TLSClientConfig: &tls.Config{RootCAs: certPool}}
+	return resp, nil
 }
 
 type entity struct {
@@ -207,7 +280,3 @@ func (e entityID) String() string {
 		return fmt.Sprintf("%v", e.ID)
 	}
 }
-
-type error422Response struct {
-	Errors []string `json:"errors"`
-}
@@ -0,0 +1,47 @@
+package client
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestNewTimeout(t *testing.T) {
+	tests := []struct {
+		name string
+		opts func(httpClient *http.Client) []Option
+		want time.Duration
+	}{
+		{"default", func(*http.Client) []Option { return nil }, 0},
+		{"timeout", func(*http.Client) []Option {
+			return []Option{WithTimeout(time.Second)}
+		}, time.Second},
+		{"timeout before HTTP client", func(httpClient *http.Client) []Option {
+			return []Option{WithTimeout(time.Second), WithHTTPClient(httpClient)}
+		}, time.Second},
+		{"timeout after HTTP client", func(httpClient *http.Client) []Option {
+			return []Option{WithHTTPClient(httpClient), WithTimeout(time.Second)}
+		}, time.Second},
+		{"HTTP client timeout", func(httpClient *http.Client) []Option {
+			return []Option{WithHTTPClient(httpClient)}
+		}, time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpClient := &http.Client{Timeout: time.Minute}
+
+			c, err := New(append(tt.opts(httpClient), WithRetry(3, nil), WithCACert(""))...)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			if c.HTTPClient.Timeout != tt.want {
+				t.Errorf("timeout = %v, want %v", c.HTTPClient.Timeout, tt.want)
+			}
+			if httpClient.Timeout != time.Minute || httpClient.Transport != nil {
+				t.Errorf("given HTTP client is changed")
+			}
+		})
+	}
+}
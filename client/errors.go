@@ -0,0 +1,93 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// maxErrorBodySize is the number of bytes of error response body that are read.
+const maxErrorBodySize = 64 << 10
+
+// maxErrorSnippetLength is the number of characters of error response body kept in APIError.
+const maxErrorSnippetLength = 200
+
+// APIError is returned when Redmine responds with error status code.
+type APIError struct {
+	StatusCode int
+	Method     string
+	Path       string
+	// Errors are messages Redmine returns for rejected request data (e.g. 'Subject cannot be blank').
+	Errors []string
+	// Body is the beginning of response body, if it does not contain error messages.
+	Body string
+}
+
+func (e *APIError) Error() string {
+	message := fmt.Sprintf("%v %v: %v %v", e.Method, e.Path, e.StatusCode, http.StatusText(e.StatusCode))
+	switch {
+	case len(e.Errors) != 0:
+		return fmt.Sprintf("%v (%v)", message, strings.Join(e.Errors, ", "))
+	case e.Body != "":
+		return fmt.Sprintf("%v (%v)", message, e.Body)
+	default:
+		return message
+	}
+}
+
+type errorsResponse struct {
+	Errors []string `json:"errors"`
+}
+
+// newAPIError creates error from response with error status code.
+func newAPIError(resp *http.Response) *APIError {
+	apiErr := &APIError{StatusCode: resp.StatusCode}
+	if resp.Request != nil {
+		apiErr.Method = resp.Request.Method
+		apiErr.Path = resp.Request.URL.Path
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+
+	var errRes errorsResponse
+	if json.Unmarshal(body, &errRes) == nil && len(errRes.Errors) != 0 {
+		apiErr.Errors = errRes.Errors
+		return apiErr
+	}
+
+	snippet := []rune(strings.Join(strings.Fields(string(body)), " "))
+	if len(snippet) > maxErrorSnippetLength {
+		snippet = append(snippet[:maxErrorSnippetLength], []rune("...")...)
+	}
+	apiErr.Body = string(snippet)
+
+	return apiErr
+}
+
+// IsNotFound reports whether error is caused by resource that does not exist (404).
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsUnauthorized reports whether error is caused by missing or invalid API key (401).
+func IsUnauthorized(err error) bool {
+	return hasStatus(err, http.StatusUnauthorized)
+}
+
+// IsForbidden reports whether error is caused by missing permissions (403).
+func IsForbidden(err error) bool {
+	return hasStatus(err, http.StatusForbidden)
+}
+
+// IsValidation reports whether error is caused by invalid request data (422).
+func IsValidation(err error) bool {
+	return hasStatus(err, http.StatusUnprocessableEntity)
+}
+
+func hasStatus(err error, status int) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == status
+}
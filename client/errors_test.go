@@ -0,0 +1,129 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAPIErrorStatus(t *testing.T) {
+	tests := []struct {
+		status                                        int
+		notFound, unauthorized, forbidden, validation bool
+	}{
+		{http.StatusNotFound, true, false, false, false},
+		{http.StatusUnauthorized, false, true, false, false},
+		{http.StatusForbidden, false, false, true, false},
+		{http.StatusUnprocessableEntity, false, false, false, true},
+		{http.StatusInternalServerError, false, false, false, false},
+	}
+
+	for _, tt := range tests {
+		c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(tt.status)
+		}))
+
+		_, err := c.GetIssue(context.Background(), 1)
+
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("%v: GetIssue() error = %v, want *APIError", tt.status, err)
+		}
+		if apiErr.StatusCode != tt.status || apiErr.Method != http.MethodGet || apiErr.Path != "/issues/1.json" {
+			t.Errorf("%v: APIError = %+v", tt.status, apiErr)
+		}
+
+		// errors are recognized even if wrapped
+		for _, err := range []error{err, fmt.Errorf("cannot get issue: %w", err)} {
+			if got := IsNotFound(err); got != tt.notFound {
+				t.Errorf("%v: IsNotFound(%v) = %v, want %v", tt.status, err, got, tt.notFound)
+			}
+			if got := IsUnauthorized(err); got != tt.unauthorized {
+				t.Errorf("%v: IsUnauthorized(%v) = %v, want %v", tt.status, err, got, tt.unauthorized)
+			}
+			if got := IsForbidden(err); got != tt.forbidden {
+				t.Errorf("%v: IsForbidden(%v) = %v, want %v", tt.status, err, got, tt.forbidden)
+			}
+			if got := IsValidation(err); got != tt.validation {
+				t.Errorf("%v: IsValidation(%v) = %v, want %v", tt.status, err, got, tt.validation)
+			}
+		}
+	}
+}
+
+func TestIsStatusOtherErrors(t *testing.T) {
+	for _, err := range []error{nil, errors.New("not found"), ErrMissingCredentials} {
+		if IsNotFound(err) || IsUnauthorized(err) || IsForbidden(err) || IsValidation(err) {
+			t.Errorf("error %v is reported as API error", err)
+		}
+	}
+}
+
+func TestAPIErrorBody(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		wantErrors []string
+		wantBody   string
+		wantError  string
+	}{
+		{
+			name:       "validation errors",
+			body:       `{"errors":["Subject cannot be blank","Tracker is not included in the list"]}`,
+			wantErrors: []string{"Subject cannot be blank", "Tracker is not included in the list"},
+			wantError: "POST /issues.json: 422 Unprocessable Entity " +
+				"(Subject cannot be blank, Tracker is not included in the list)",
+		},
+		{
+			name:      "empty errors",
+			body:      `{"errors":[]}`,
+			wantBody:  `{"errors":[]}`,
+			wantError: `POST /issues.json: 422 Unprocessable Entity ({"errors":[]})`,
+		},
+		{
+			name:      "HTML",
+			body:      "<html>\n  <body>Unprocessable</body>\n</html>\n",
+			wantBody:  "<html> <body>Unprocessable</body> </html>",
+			wantError: "POST /issues.json: 422 Unprocessable Entity (<html> <body>Unprocessable</body> </html>)",
+		},
+		{
+			name:      "long body",
+			body:      strings.Repeat("č", 300),
+			wantBody:  strings.Repeat("č", maxErrorSnippetLength) + "...",
+			wantError: "POST /issues.json: 422 Unprocessable Entity (" + strings.Repeat("č", maxErrorSnippetLength) + "...)",
+		},
+		{
+			name:      "no body",
+			wantError: "POST /issues.json: 422 Unprocessable Entity",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+
+			_, err := c.CreateIssue(context.Background(), IssuePost{})
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("CreateIssue() error = %v, want *APIError", err)
+			}
+			if !reflect.DeepEqual(apiErr.Errors, tt.wantErrors) {
+				t.Errorf("Errors = %q, want %q", apiErr.Errors, tt.wantErrors)
+			}
+			if apiErr.Body != tt.wantBody {
+				t.Errorf("Body = %q, want %q", apiErr.Body, tt.wantBody)
+			}
+			if err.Error() != tt.wantError {
+				t.Errorf("Error() = %q, want %q", err.Error(), tt.wantError)
+			}
+		})
+	}
+}
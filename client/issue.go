@@ -1,20 +1,95 @@
 package client
 
 import (
+	"context"
 	"fmt"
-	"net/url"
-
-	"github.com/mightymatth/arcli/config"
-
-	"github.com/spf13/viper"
+	"time"
 )
 
 // Issue correspond with issue in Redmine.
 type Issue struct {
-	ID          int64  `json:"id"`
-	Project     entity `json:"project"`
-	Subject     string `json:"subject"`
-	Description string `json:"description"`
+	ID             int64              `json:"id"`
+	Project        entity             `json:"project"`
+	Tracker        entity             `json:"tracker"`
+	Status         entity             `json:"status"`
+	Priority       entity             `json:"priority"`
+	Author         entity             `json:"author"`
+	AssignedTo     *entity            `json:"assigned_to,omitempty"`
+	FixedVersion   *entity            `json:"fixed_version,omitempty"`
+	Parent         *entityID          `json:"parent,omitempty"`
+	Subject        string             `json:"subject"`
+	Description    string             `json:"description"`
+	StartDate      *DateTime          `json:"start_date,omitempty"`
+	DueDate        *DateTime          `json:"due_date,omitempty"`
+	DoneRatio      int                `json:"done_ratio"`
+	EstimatedHours *float64           `json:"estimated_hours,omitempty"`
+	SpentHours     *float64           `json:"spent_hours,omitempty"`
+	CustomFields   []CustomFieldValue `json:"custom_fields,omitempty"`
+	CreatedOn      time.Time          `json:"created_on"`
+	UpdatedOn      time.Time          `json:"updated_on"`
+	ClosedOn       *time.Time         `json:"closed_on,omitempty"`
+	Journals       []Journal          `json:"journals,omitempty"`
+	Children       []IssueChild       `json:"children,omitempty"`
+	Relations      []IssueRelation    `json:"relations,omitempty"`
+	Attachments    []Attachment       `json:"attachments,omitempty"`
+	Watchers       []entity           `json:"watchers,omitempty"`
+	Changesets     []Changeset        `json:"changesets,omitempty"`
+}
+
+// Journal represents a single change in issue history, with its notes and
+// changed fields.
+type Journal struct {
+	ID        int64           `json:"id"`
+	User      entity          `json:"user"`
+	Notes     string          `json:"notes"`
+	CreatedOn time.Time       `json:"created_on"`
+	Details   []JournalDetail `json:"details"`
+}
+
+// JournalDetail represents change of a single issue field. Property is 'attr' for
+// issue attributes, 'cf' for custom fields, 'attachment' or 'relation'.
+type JournalDetail struct {
+	Property string  `json:"property"`
+	Name     string  `json:"name"`
+	OldValue *string `json:"old_value"`
+	NewValue *string `json:"new_value"`
+}
+
+// IssueChild represents subtask of issue, with its own subtasks.
+type IssueChild struct {
+	ID       int64        `json:"id"`
+	Tracker  entity       `json:"tracker"`
+	Subject  string       `json:"subject"`
+	Children []IssueChild `json:"children,omitempty"`
+}
+
+// IssueRelation represents relation between two issues (e.g. 'relates', 'blocks').
+type IssueRelation struct {
+	ID           int64  `json:"id"`
+	IssueID      int64  `json:"issue_id"`
+	IssueToID    int64  `json:"issue_to_id"`
+	RelationType string `json:"relation_type"`
+	Delay        *int   `json:"delay,omitempty"`
+}
+
+// Attachment represents file attached to issue.
+type Attachment struct {
+	ID          int64     `json:"id"`
+	Filename    string    `json:"filename"`
+	Filesize    int64     `json:"filesize"`
+	ContentType string    `json:"content_type"`
+	Description string    `json:"description"`
+	ContentURL  string    `json:"content_url"`
+	Author      entity    `json:"author"`
+	CreatedOn   time.Time `json:"created_on"`
+}
+
+// Changeset represents repository commit associated with issue.
+type Changeset struct {
+	Revision    string    `json:"revision"`
+	User        *entity   `json:"user,omitempty"`
+	Comments    string    `json:"comments"`
+	CommittedOn time.Time `json:"committed_on"`
 }
 
 type issueResponse struct {
@@ -23,11 +98,20 @@ type issueResponse struct {
 
 type issuesResponse struct {
 	Issues []Issue `json:"issues"`
+	pageEnvelope
 }
 
-// GetIssue fetches issue with requested ID.
-func (c *Client) GetIssue(id int64) (*Issue, error) {
-	req, err := c.getRequest(fmt.Sprintf("/issues/%v.json", id), "")
+func (r *issuesResponse) items() []Issue {
+	return r.Issues
+}
+
+// issueIncludes are associated data fetched together with single issue.
+const issueIncludes = "journals,children,relations,attachments,watchers,changesets"
+
+// GetIssue fetches issue with requested ID, together with its journals, children,
+// relations, attachments, watchers and changesets.
+func (c *Client) GetIssue(ctx context.Context, id int64) (*Issue, error) {
+	req, err := c.getRequest(ctx, fmt.Sprintf("/issues/%v.json", id), "include="+issueIncludes)
 	if err != nil {
 		return nil, err
 	}
@@ -41,48 +125,90 @@ func (c *Client) GetIssue(id int64) (*Issue, error) {
 	return &response.Issue, nil
 }
 
-// GetMyIssues fetches issues assigned only to currently logged user.
-func (c *Client) GetMyIssues() ([]Issue, error) {
-	params := fmt.Sprintf("assigned_to_id=%v", viper.GetString(config.UserID))
-	return c.GetIssues(params)
+type issueBody struct {
+	Issue IssuePost `json:"issue"`
 }
 
-// GetMyRelatedIssues fetches issues assigned to currently logged user.
-func (c *Client) GetMyRelatedIssues() ([]Issue, error) {
-	return c.GetIssues("assigned_to_id=me")
+// IssuePost represents data which should be placed to request body
+// while creating a new issue or updating an existing one.
+type IssuePost struct {
+	ProjectID      int64              `json:"project_id,omitempty"`
+	TrackerID      int64              `json:"tracker_id,omitempty"`
+	StatusID       int64              `json:"status_id,omitempty"`
+	Subject        string             `json:"subject,omitempty"`
+	Description    string             `json:"description,omitempty"`
+	PriorityID     int64              `json:"priority_id,omitempty"`
+	AssignedToID   int64              `json:"assigned_to_id,omitempty"`
+	FixedVersionID int64              `json:"fixed_version_id,omitempty"`
+	ParentIssueID  int64              `json:"parent_issue_id,omitempty"`
+	StartDate      *DateTime          `json:"start_date,omitempty"`
+	DueDate        *DateTime          `json:"due_date,omitempty"`
+	DoneRatio      *int               `json:"done_ratio,omitempty"`
+	EstimatedHours float64            `json:"estimated_hours,omitempty"`
+	CustomFields   []CustomFieldValue `json:"custom_fields,omitempty"`
+	Notes          string             `json:"notes,omitempty"`
 }
 
-// GetMyWatchedIssues fetches issues that currently logged user watches.
-func (c *Client) GetMyWatchedIssues() ([]Issue, error) {
-	return c.GetIssues("set_filter=1&sort=updated_on%3Adesc&watcher_id=me")
+// CustomFieldValue represents value of issue custom field.
+type CustomFieldValue struct {
+	ID    int64       `json:"id"`
+	Name  string      `json:"name,omitempty"`
+	Value interface{} `json:"value"`
 }
 
-// GetIssues fetches issues with rules defined in queryParams.
-func (c *Client) GetIssues(queryParams string) ([]Issue, error) {
-	req, err := c.getRequest("/issues.json", queryParams)
+// CreateIssue creates new issue.
+func (c *Client) CreateIssue(ctx context.Context, issue IssuePost) (*Issue, error) {
+	req, err := c.postRequest(ctx, "/issues.json", issueBody{Issue: issue})
 	if err != nil {
 		return nil, err
 	}
 
-	var response issuesResponse
+	var response issueResponse
 	_, err = c.Do(req, &response)
 	if err != nil {
 		return nil, err
 	}
 
-	return response.Issues, nil
+	return &response.Issue, nil
 }
 
-// URL returns issue URL.
-func (i *Issue) URL() string {
-	host, _ := getCredentials()
-
-	u, err := url.Parse(host)
+// UpdateIssue updates issue with requested ID. Notes are added to issue history.
+func (c *Client) UpdateIssue(ctx context.Context, id int64, issue IssuePost) error {
+	req, err := c.putRequest(ctx, fmt.Sprintf("/issues/%v.json", id), issueBody{Issue: issue})
 	if err != nil {
-		return ""
+		return err
 	}
 
-	u.Path = fmt.Sprintf("/issues/%v", i.ID)
+	_, err = c.Do(req, nil)
+	return err
+}
+
+// GetUserIssues fetches issues assigned only to user with given ID.
+func (c *Client) GetUserIssues(ctx context.Context, userID int64) ([]Issue, error) {
+	return c.GetIssues(ctx, fmt.Sprintf("assigned_to_id=%v", userID))
+}
+
+// GetMyRelatedIssues fetches issues assigned to currently logged user.
+func (c *Client) GetMyRelatedIssues(ctx context.Context) ([]Issue, error) {
+	return c.GetIssues(ctx, "assigned_to_id=me")
+}
+
+// GetMyWatchedIssues fetches issues that currently logged user watches.
+func (c *Client) GetMyWatchedIssues(ctx context.Context) ([]Issue, error) {
+	return c.GetIssues(ctx, "set_filter=1&sort=updated_on%3Adesc&watcher_id=me")
+}
+
+// GetIssues fetches all pages of issues with rules defined in queryParams.
+func (c *Client) GetIssues(ctx context.Context, queryParams string) ([]Issue, error) {
+	return fetchAll(ctx, c, "/issues.json", queryParams, newIssuesPage)
+}
+
+// StreamIssues fetches all pages of issues with rules defined in queryParams and
+// calls fn for each page as soon as it arrives, in order.
+func (c *Client) StreamIssues(ctx context.Context, queryParams string, fn func([]Issue) error) error {
+	return streamPages(ctx, c, "/issues.json", queryParams, newIssuesPage, fn)
+}
 
-	return u.String()
+func newIssuesPage() page[Issue] {
+	return &issuesResponse{}
 }
@@ -0,0 +1,203 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// PageSize is the number of records requested per page. Redmine caps the limit
+// parameter at 100 by default, so bigger values would silently return less.
+const PageSize = 100
+
+// DefaultPageConcurrency is the number of pages fetched in parallel when
+// Client.PageConcurrency is not set.
+const DefaultPageConcurrency = 4
+
+// pageEnvelope represents pagination data that Redmine adds to every list response.
+type pageEnvelope struct {
+	TotalCount int `json:"total_count"`
+	Offset     int `json:"offset"`
+	Limit      int `json:"limit"`
+}
+
+func (e pageEnvelope) envelope() pageEnvelope {
+	return e
+}
+
+// page is implemented by list responses that embed pageEnvelope.
+type page[T any] interface {
+	items() []T
+	envelope() pageEnvelope
+}
+
+type pageResult[T any] struct {
+	items []T
+	err   error
+}
+
+// fetchAll fetches every page of the list resource on path and returns all records.
+func fetchAll[T any](ctx context.Context, c *Client, path, queryParams string, newPage func() page[T]) ([]T, error) {
+	all := make([]T, 0)
+	err := streamPages(ctx, c, path, queryParams, newPage, func(items []T) error {
+		all = append(all, items...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return all, nil
+}
+
+// streamPages fetches every page of the list resource on path and calls fn with
+// records of each page, in order. The first page is fetched alone to find out the
+// total count, the rest of them are fetched in parallel. Parameter "limit" in
+// queryParams caps the total number of fetched records, while "offset" sets the
+// position of the first one.
+func streamPages[T any](ctx context.Context, c *Client, path, queryParams string, newPage func() page[T], fn func([]T) error) error {
+	query, err := url.ParseQuery(queryParams)
+	if err != nil {
+		return err
+	}
+
+	start, err := intParam(query, "offset", 0)
+	if err != nil {
+		return err
+	}
+	max, err := intParam(query, "limit", -1)
+	if err != nil {
+		return err
+	}
+
+	size := PageSize
+	if max >= 0 && max < size {
+		size = max
+	}
+	if size == 0 {
+		return nil
+	}
+
+	first, env, err := fetchPage(ctx, c, path, query, start, size, newPage)
+	if err != nil {
+		return err
+	}
+	if err = fn(first); err != nil {
+		return err
+	}
+
+	// server can be configured to return smaller pages than requested
+	if env.Limit > 0 && env.Limit < size {
+		size = env.Limit
+	}
+
+	end := env.TotalCount
+	if max >= 0 && start+max < end {
+		end = start + max
+	}
+
+	var offsets []int
+	for offset := start + size; offset < end; offset += size {
+		offsets = append(offsets, offset)
+	}
+	if len(offsets) == 0 {
+		return nil
+	}
+
+	results := make([]chan pageResult[T], len(offsets))
+	for i := range results {
+		results[i] = make(chan pageResult[T], 1)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	var g errgroup.Group
+	g.SetLimit(c.pageConcurrency())
+
+	launched := make(chan struct{})
+	go func() {
+		defer close(launched)
+		for i, offset := range offsets {
+			i, offset := i, offset
+			g.Go(func() error {
+				if ctx.Err() != nil {
+					results[i] <- pageResult[T]{err: ctx.Err()}
+					return nil
+				}
+
+				limit := size
+				if offset+limit > end {
+					limit = end - offset
+				}
+
+				items, _, err := fetchPage(ctx, c, path, query, offset, limit, newPage)
+				results[i] <- pageResult[T]{items: items, err: err}
+				return nil
+			})
+		}
+	}()
+	defer func() {
+		cancel()
+		<-launched
+		_ = g.Wait()
+	}()
+
+	for i := range results {
+		result := <-results[i]
+		if result.err != nil {
+			return result.err
+		}
+		if err = fn(result.items); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func fetchPage[T any](ctx context.Context, c *Client, path string, query url.Values, offset, limit int,
+	newPage func() page[T]) ([]T, pageEnvelope, error) {
+	pageQuery := make(url.Values, len(query)+2)
+	for key, values := range query {
+		pageQuery[key] = values
+	}
+	pageQuery.Set("offset", strconv.Itoa(offset))
+	pageQuery.Set("limit", strconv.Itoa(limit))
+
+	req, err := c.getRequest(ctx, path, pageQuery.Encode())
+	if err != nil {
+		return nil, pageEnvelope{}, err
+	}
+
+	response := newPage()
+	_, err = c.Do(req, response)
+	if err != nil {
+		return nil, pageEnvelope{}, err
+	}
+
+	return response.items(), response.envelope(), nil
+}
+
+func (c *Client) pageConcurrency() int {
+	if c.PageConcurrency > 0 {
+		return c.PageConcurrency
+	}
+
+	return DefaultPageConcurrency
+}
+
+func intParam(query url.Values, key string, fallback int) (int, error) {
+	value := query.Get(key)
+	if value == "" {
+		return fallback, nil
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %v parameter: %v", key, value)
+	}
+
+	return n, nil
+}
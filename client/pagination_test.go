@@ -0,0 +1,176 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// timeEntriesServer serves total time entries with IDs from 1, in pages of at
+// most maxLimit entries.
+type timeEntriesServer struct {
+	total, maxLimit int
+	delay           time.Duration
+
+	mu       sync.Mutex
+	offsets  []int
+	inFlight int
+	peak     int
+}
+
+func (s *timeEntriesServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.inFlight++
+	s.peak = max(s.peak, s.inFlight)
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		s.inFlight--
+		s.mu.Unlock()
+	}()
+	time.Sleep(s.delay)
+
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	limit = min(limit, s.maxLimit)
+
+	s.mu.Lock()
+	s.offsets = append(s.offsets, offset)
+	s.mu.Unlock()
+
+	response := timeEntriesResponse{
+		TimeEntries:  []TimeEntry{},
+		pageEnvelope: pageEnvelope{TotalCount: s.total, Offset: offset, Limit: limit},
+	}
+	for id := offset + 1; id <= min(offset+limit, s.total); id++ {
+		response.TimeEntries = append(response.TimeEntries, TimeEntry{ID: int64(id)})
+	}
+
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+func newTestClient(t *testing.T, handler http.Handler, opts ...Option) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	c, err := New(append([]Option{WithBaseURL(server.URL), WithAPIKey("key")}, opts...)...)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	return c
+}
+
+func TestGetTimeEntriesPages(t *testing.T) {
+	tests := []struct {
+		name        string
+		total       int
+		maxLimit    int
+		query       string
+		wantFirst   int64
+		wantCount   int
+		wantOffsets int
+	}{
+		{"empty", 0, 100, "", 0, 0, 1},
+		{"single page", 42, 100, "", 1, 42, 1},
+		{"several pages", 250, 100, "", 1, 250, 3},
+		{"server caps limit", 120, 25, "", 1, 120, 5},
+		{"limit", 250, 100, "limit=130", 1, 130, 2},
+		{"limit smaller than page", 250, 100, "limit=5", 1, 5, 1},
+		{"offset", 250, 100, "offset=200", 201, 50, 1},
+		{"offset and limit", 250, 100, "offset=10&limit=150", 11, 150, 2},
+		{"offset past total", 20, 100, "offset=30", 0, 0, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &timeEntriesServer{total: tt.total, maxLimit: tt.maxLimit}
+			c := newTestClient(t, server)
+
+			entries, err := c.GetTimeEntries(context.Background(), tt.query)
+			if err != nil {
+				t.Fatalf("GetTimeEntries() error = %v", err)
+			}
+
+			if entries == nil {
+				t.Errorf("GetTimeEntries() = nil, want empty slice")
+			}
+			if len(entries) != tt.wantCount {
+				t.Fatalf("GetTimeEntries() returned %v entries, want %v", len(entries), tt.wantCount)
+			}
+			for i, entry := range entries {
+				if entry.ID != tt.wantFirst+int64(i) {
+					t.Fatalf("entry %v has ID %v, want %v", i, entry.ID, tt.wantFirst+int64(i))
+				}
+			}
+			if len(server.offsets) != tt.wantOffsets {
+				t.Errorf("requested %v pages (offsets %v), want %v", len(server.offsets), server.offsets,
+					tt.wantOffsets)
+			}
+		})
+	}
+}
+
+func TestStreamTimeEntriesOrder(t *testing.T) {
+	server := &timeEntriesServer{total: 1000, maxLimit: 100}
+	c := newTestClient(t, server)
+
+	var next int64 = 1
+	pages := 0
+	err := c.StreamTimeEntries(context.Background(), "", func(entries []TimeEntry) error {
+		pages++
+		for _, entry := range entries {
+			if entry.ID != next {
+				t.Fatalf("entry has ID %v, want %v", entry.ID, next)
+			}
+			next++
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("StreamTimeEntries() error = %v", err)
+	}
+
+	if pages != 10 || next != 1001 {
+		t.Errorf("streamed %v pages with %v entries, want 10 pages with 1000 entries", pages, next-1)
+	}
+}
+
+func TestPageConcurrency(t *testing.T) {
+	tests := []struct {
+		name        string
+		concurrency int
+		want        int
+	}{
+		{"default", 0, DefaultPageConcurrency},
+		{"one", 1, 1},
+		{"two", 2, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &timeEntriesServer{total: 1000, maxLimit: 100, delay: 20 * time.Millisecond}
+			c := newTestClient(t, server)
+			c.PageConcurrency = tt.concurrency
+
+			entries, err := c.GetTimeEntries(context.Background(), "")
+			if err != nil {
+				t.Fatalf("GetTimeEntries() error = %v", err)
+			}
+			if len(entries) != 1000 {
+				t.Fatalf("GetTimeEntries() returned %v entries, want 1000", len(entries))
+			}
+
+			if server.peak > tt.want {
+				t.Errorf("%v pages were fetched in parallel, want at most %v", server.peak, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package client
+
+import "context"
+
+// IssuePriority represents Redmine issue priority.
+type IssuePriority struct {
+	ID        int64  `json:"id"`
+	Name      string `json:"name"`
+	IsDefault bool   `json:"is_default"`
+}
+
+// IssuePriorities represents a list of issue priorities.
+type IssuePriorities []IssuePriority
+
+type issuePrioritiesResponse struct {
+	IssuePriorities IssuePriorities `json:"issue_priorities"`
+}
+
+// GetIssuePriorities fetches all priorities that can be set on issue.
+func (c *Client) GetIssuePriorities(ctx context.Context) (IssuePriorities, error) {
+	req, err := c.getRequest(ctx, "/enumerations/issue_priorities.json", "")
+	if err != nil {
+		return nil, err
+	}
+
+	var response issuePrioritiesResponse
+	_, err = c.Do(req, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.IssuePriorities, nil
+}
+
+// Valid checks whether priority with provided name parameter exists. If yes,
+// returns its ID and true as second parameter; if not, return false as second parameter.
+func (prs IssuePriorities) Valid(name string) (int64, bool) {
+	for _, priority := range prs {
+		if priority.Name == name {
+			return priority.ID, true
+		}
+	}
+
+	return 0, false
+}
+
+// Names returns priority names.
+func (prs IssuePriorities) Names() []string {
+	names := make([]string, 0, len(prs))
+	for _, priority := range prs {
+		names = append(names, priority.Name)
+	}
+
+	return names
+}
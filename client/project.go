@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"time"
@@ -19,6 +20,11 @@ type Project struct {
 
 type projectsResponse struct {
 	Projects []Project `json:"projects"`
+	pageEnvelope
+}
+
+func (r *projectsResponse) items() []Project {
+	return r.Projects
 }
 
 type projectResponse struct {
@@ -26,8 +32,8 @@ type projectResponse struct {
 }
 
 // GetProject fetches project with requested ID.
-func (c *Client) GetProject(id int64) (*Project, error) {
-	req, err := c.getRequest(fmt.Sprintf("/projects/%v.json", id), "")
+func (c *Client) GetProject(ctx context.Context, id int64) (*Project, error) {
+	req, err := c.getRequest(ctx, fmt.Sprintf("/projects/%v.json", id), "")
 	if err != nil {
 		return nil, err
 	}
@@ -41,32 +47,33 @@ func (c *Client) GetProject(id int64) (*Project, error) {
 	return &response.Project, nil
 }
 
-// GetProjects fetches all projects viewable by currently logged user.
-func (c *Client) GetProjects() ([]Project, error) {
-	req, err := c.getRequest("/projects.json", "limit=200")
+// GetProjectByIdentifier fetches project with requested identifier (e.g. 'webshop').
+func (c *Client) GetProjectByIdentifier(ctx context.Context, identifier string) (*Project, error) {
+	req, err := c.getRequest(ctx, fmt.Sprintf("/projects/%v.json", url.PathEscape(identifier)), "")
 	if err != nil {
 		return nil, err
 	}
 
-	var response projectsResponse
+	var response projectResponse
 	_, err = c.Do(req, &response)
 	if err != nil {
 		return nil, err
 	}
 
-	return response.Projects, nil
+	return &response.Project, nil
 }
 
-// URL returns project URL.
-func (p *Project) URL() string {
-	host, _ := getCredentials()
-
-	u, err := url.Parse(host)
-	if err != nil {
-		return ""
-	}
+// GetProjects fetches all projects viewable by currently logged user.
+func (c *Client) GetProjects(ctx context.Context) ([]Project, error) {
+	return fetchAll(ctx, c, "/projects.json", "", newProjectsPage)
+}
 
-	u.Path = fmt.Sprintf("/projects/%v", p.ID)
+// StreamProjects fetches all projects viewable by currently logged user and
+// calls fn for each page as soon as it arrives, in order.
+func (c *Client) StreamProjects(ctx context.Context, fn func([]Project) error) error {
+	return streamPages(ctx, c, "/projects.json", "", newProjectsPage, fn)
+}
 
-	return u.String()
+func newProjectsPage() page[Project] {
+	return &projectsResponse{}
 }
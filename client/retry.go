@@ -0,0 +1,199 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// DefaultMaxAttempts is the number of attempts of request made by RetryTransport,
+	// if not set.
+	DefaultMaxAttempts = 3
+	// DefaultMinBackoff is the delay before the first retry, which doubles with every next one.
+	DefaultMinBackoff = 500 * time.Millisecond
+	// DefaultMaxBackoff is the longest delay between retries.
+	DefaultMaxBackoff = 10 * time.Second
+)
+
+// RetryTransport retries idempotent requests (GET, HEAD, PUT and DELETE) that fail
+// with network error or with status code telling that server is temporarily
+// unavailable (429, 502, 503, 504). Delay between attempts grows exponentially with
+// random jitter, unless server sets it in Retry-After header. Other requests (e.g.
+// POST) are retried only if their context is created by AllowRetry.
+type RetryTransport struct {
+	// Base makes the requests (http.DefaultTransport is used if not set).
+	Base http.RoundTripper
+	// MaxAttempts is the number of attempts including the first one
+	// (DefaultMaxAttempts is used if not set).
+	MaxAttempts int
+	// MinBackoff is the delay before the first retry (DefaultMinBackoff is used if not set).
+	MinBackoff time.Duration
+	// MaxBackoff caps the delay between retries (DefaultMaxBackoff is used if not set).
+	MaxBackoff time.Duration
+	// Logf, if set, is called before every retry with its reason.
+	Logf func(format string, args ...interface{})
+}
+
+type allowRetryKey struct{}
+
+// AllowRetry returns context which makes RetryTransport retry requests regardless
+// of their method. It should be used only for requests that are safe to repeat.
+func AllowRetry(ctx context.Context) context.Context {
+	return context.WithValue(ctx, allowRetryKey{}, true)
+}
+
+// WithRetry makes client retry failed idempotent requests as described in
+// RetryTransport, with at most maxAttempts attempts. Logf (if not nil) is called
+// before every retry.
+func WithRetry(maxAttempts int, logf func(format string, args ...interface{})) Option {
+	return func(c *Client) error {
+		c.HTTPClient.Transport = &RetryTransport{
+			Base:        c.HTTPClient.Transport,
+			MaxAttempts: maxAttempts,
+			Logf:        logf,
+		}
+		return nil
+	}
+}
+
+// RoundTrip makes the request and retries it if needed.
+func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	maxAttempts := t.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultMaxAttempts
+	}
+	if !t.retryable(req) {
+		maxAttempts = 1
+	}
+
+	for attempt := 1; ; attempt++ {
+		attemptReq, err := rewindRequest(req, attempt)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := t.base().RoundTrip(attemptReq)
+		if attempt == maxAttempts || req.Context().Err() != nil || !shouldRetry(resp, err) {
+			return resp, err
+		}
+
+		delay := t.backoff(attempt)
+		reason := fmt.Sprint(err)
+		if resp != nil {
+			reason = resp.Status
+			if after, ok := retryAfter(resp); ok {
+				delay = after
+			}
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxErrorBodySize))
+			_ = resp.Body.Close()
+		}
+
+		if t.Logf != nil {
+			t.Logf("%v %v: %v, retrying in %v (attempt %v of %v)", req.Method, req.URL.Path, reason,
+				delay.Round(time.Millisecond), attempt+1, maxAttempts)
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (t *RetryTransport) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+
+	return http.DefaultTransport
+}
+
+func (t *RetryTransport) retryable(req *http.Request) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		allowed, _ := req.Context().Value(allowRetryKey{}).(bool)
+		return allowed
+	}
+}
+
+// backoff returns delay before the retry following given attempt, which is
+// exponential backoff with random jitter of up to a half of it.
+func (t *RetryTransport) backoff(attempt int) time.Duration {
+	minBackoff, maxBackoff := t.MinBackoff, t.MaxBackoff
+	if minBackoff <= 0 {
+		minBackoff = DefaultMinBackoff
+	}
+	if maxBackoff <= 0 {
+		maxBackoff = DefaultMaxBackoff
+	}
+
+	delay := maxBackoff
+	if attempt < 32 {
+		delay = min(minBackoff<<(attempt-1), maxBackoff)
+	}
+
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+// rewindRequest returns request with fresh body for given attempt.
+func rewindRequest(req *http.Request, attempt int) (*http.Request, error) {
+	if attempt == 1 || req.GetBody == nil {
+		return req, nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+
+	rewound := req.Clone(req.Context())
+	rewound.Body = body
+
+	return rewound, nil
+}
+
+func shouldRetry(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// retryAfter returns delay set in Retry-After header, given either in seconds or as date.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+
+	return 0, false
+}
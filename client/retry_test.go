@@ -0,0 +1,285 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// roundTripFunc is http.RoundTripper implemented by function.
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// statusResponder responds with given status codes in turn, and the last one afterwards.
+// Bodies of requests are recorded.
+type statusResponder struct {
+	statuses []int
+	header   http.Header
+	bodies   []string
+}
+
+func (r *statusResponder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		body, _ = io.ReadAll(req.Body)
+	}
+	r.bodies = append(r.bodies, string(body))
+
+	status := r.statuses[min(len(r.bodies), len(r.statuses))-1]
+	return &http.Response{
+		StatusCode: status,
+		Status:     http.StatusText(status),
+		Header:     r.header,
+		Body:       io.NopCloser(strings.NewReader("")),
+		Request:    req,
+	}, nil
+}
+
+func newRetryTransport(base http.RoundTripper) *RetryTransport {
+	return &RetryTransport{Base: base, MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+}
+
+func TestRetryTransportMethods(t *testing.T) {
+	tests := []struct {
+		method     string
+		allowRetry bool
+		want       int
+	}{
+		{http.MethodGet, false, 3},
+		{http.MethodPut, false, 3},
+		{http.MethodDelete, false, 3},
+		{http.MethodPost, false, 1},
+		{http.MethodPost, true, 3},
+	}
+
+	for _, tt := range tests {
+		responder := &statusResponder{statuses: []int{http.StatusServiceUnavailable}}
+
+		ctx := context.Background()
+		if tt.allowRetry {
+			ctx = AllowRetry(ctx)
+		}
+		req, _ := http.NewRequestWithContext(ctx, tt.method, "http://redmine/issues.json", nil)
+
+		resp, err := newRetryTransport(responder).RoundTrip(req)
+		if err != nil {
+			t.Fatalf("%v: RoundTrip() error = %v", tt.method, err)
+		}
+		if resp.StatusCode != http.StatusServiceUnavailable {
+			t.Errorf("%v: status = %v, want %v", tt.method, resp.StatusCode, http.StatusServiceUnavailable)
+		}
+		if len(responder.bodies) != tt.want {
+			t.Errorf("%v (allow retry %v): %v attempts, want %v", tt.method, tt.allowRetry,
+				len(responder.bodies), tt.want)
+		}
+	}
+}
+
+func TestRetryTransportStatuses(t *testing.T) {
+	tests := []struct {
+		status int
+		want   int
+	}{
+		{http.StatusOK, 1},
+		{http.StatusNotFound, 1},
+		{http.StatusUnprocessableEntity, 1},
+		{http.StatusInternalServerError, 1},
+		{http.StatusTooManyRequests, 3},
+		{http.StatusBadGateway, 3},
+		{http.StatusServiceUnavailable, 3},
+		{http.StatusGatewayTimeout, 3},
+	}
+
+	for _, tt := range tests {
+		responder := &statusResponder{statuses: []int{tt.status}}
+		req, _ := http.NewRequest(http.MethodGet, "http://redmine/issues.json", nil)
+
+		_, err := newRetryTransport(responder).RoundTrip(req)
+		if err != nil {
+			t.Fatalf("%v: RoundTrip() error = %v", tt.status, err)
+		}
+		if len(responder.bodies) != tt.want {
+			t.Errorf("%v: %v attempts, want %v", tt.status, len(responder.bodies), tt.want)
+		}
+	}
+}
+
+func TestRetryTransportRecovers(t *testing.T) {
+	responder := &statusResponder{statuses: []int{http.StatusBadGateway, http.StatusOK}}
+	req, _ := http.NewRequest(http.MethodGet, "http://redmine/issues.json", nil)
+
+	var logged []string
+	transport := newRetryTransport(responder)
+	transport.Logf = func(format string, args ...interface{}) {
+		logged = append(logged, format)
+	}
+
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip() error = %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("status = %v, want %v", resp.StatusCode, http.StatusOK)
+	}
+	if len(responder.bodies) != 2 || len(logged) != 1 {
+		t.Errorf("%v attempts with %v logged retries, want 2 attempts and 1 retry",
+			len(responder.bodies), len(logged))
+	}
+}
+
+func TestRetryTransportNetworkError(t *testing.T) {
+	attempts := 0
+	base := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		attempts++
+		return nil, errors.New("connection refused")
+	})
+	req, _ := http.NewRequest(http.MethodGet, "http://redmine/issues.json", nil)
+
+	_, err := newRetryTransport(base).RoundTrip(req)
+	if err == nil {
+		t.Fatalf("RoundTrip() error = nil, want connection error")
+	}
+	if attempts != 3 {
+		t.Errorf("%v attempts, want 3", attempts)
+	}
+}
+
+func TestRetryTransportRewindsBody(t *testing.T) {
+	responder := &statusResponder{statuses: []int{http.StatusServiceUnavailable, http.StatusOK}}
+	body := `{"issue":{"subject":"retried"}}`
+	req, _ := http.NewRequest(http.MethodPut, "http://redmine/issues/1.json", bytes.NewBufferString(body))
+
+	_, err := newRetryTransport(responder).RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip() error = %v", err)
+	}
+
+	if len(responder.bodies) != 2 {
+		t.Fatalf("%v attempts, want 2", len(responder.bodies))
+	}
+	for i, got := range responder.bodies {
+		if got != body {
+			t.Errorf("attempt %v sent body %q, want %q", i+1, got, body)
+		}
+	}
+}
+
+func TestRetryTransportBodyWithoutGetBody(t *testing.T) {
+	responder := &statusResponder{statuses: []int{http.StatusServiceUnavailable}}
+	req, _ := http.NewRequest(http.MethodPut, "http://redmine/issues/1.json", nil)
+	req.Body = io.NopCloser(strings.NewReader("once"))
+
+	_, _ = newRetryTransport(responder).RoundTrip(req)
+
+	if len(responder.bodies) != 1 {
+		t.Errorf("%v attempts, want 1 (body cannot be sent again)", len(responder.bodies))
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	date := time.Now().Add(90 * time.Second).UTC().Format(http.TimeFormat)
+	past := time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat)
+
+	tests := []struct {
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{"", 0, false},
+		{"0", 0, true},
+		{"5", 5 * time.Second, true},
+		{"120", 2 * time.Minute, true},
+		{"-1", 0, false},
+		{"soon", 0, false},
+		{date, 90 * time.Second, true},
+		{past, 0, true},
+	}
+
+	for _, tt := range tests {
+		resp := &http.Response{Header: http.Header{}}
+		if tt.value != "" {
+			resp.Header.Set("Retry-After", tt.value)
+		}
+
+		got, ok := retryAfter(resp)
+		if ok != tt.wantOK {
+			t.Errorf("retryAfter(%q) ok = %v, want %v", tt.value, ok, tt.wantOK)
+			continue
+		}
+		// dates have one second precision
+		if got < tt.want-2*time.Second || got > tt.want {
+			t.Errorf("retryAfter(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestRetryTransportUsesRetryAfter(t *testing.T) {
+	responder := &statusResponder{
+		statuses: []int{http.StatusTooManyRequests, http.StatusOK},
+		header:   http.Header{"Retry-After": []string{"1"}},
+	}
+	req, _ := http.NewRequest(http.MethodGet, "http://redmine/issues.json", nil)
+
+	start := time.Now()
+	_, err := newRetryTransport(responder).RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip() error = %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("retried after %v, want at least 1s set in Retry-After", elapsed)
+	}
+}
+
+func TestRetryTransportCancelDuringBackoff(t *testing.T) {
+	responder := &statusResponder{statuses: []int{http.StatusServiceUnavailable}}
+	ctx, cancel := context.WithCancel(context.Background())
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "http://redmine/issues.json", nil)
+
+	transport := newRetryTransport(responder)
+	transport.MinBackoff, transport.MaxBackoff = time.Minute, time.Minute
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err := transport.RoundTrip(req)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("RoundTrip() error = %v, want %v", err, context.Canceled)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("RoundTrip() returned after %v, want it to stop waiting when cancelled", elapsed)
+	}
+	if len(responder.bodies) != 1 {
+		t.Errorf("%v attempts, want 1", len(responder.bodies))
+	}
+}
+
+func TestRetryTransportBackoff(t *testing.T) {
+	transport := &RetryTransport{MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	tests := []struct {
+		attempt  int
+		min, max time.Duration
+	}{
+		{1, 50 * time.Millisecond, 100 * time.Millisecond},
+		{2, 100 * time.Millisecond, 200 * time.Millisecond},
+		{3, 200 * time.Millisecond, 400 * time.Millisecond},
+		{5, 500 * time.Millisecond, time.Second},
+		{40, 500 * time.Millisecond, time.Second},
+	}
+
+	for _, tt := range tests {
+		for i := 0; i < 20; i++ {
+			if got := transport.backoff(tt.attempt); got < tt.min || got > tt.max {
+				t.Errorf("backoff(%v) = %v, want between %v and %v", tt.attempt, got, tt.min, tt.max)
+			}
+		}
+	}
+}
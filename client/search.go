@@ -1,8 +1,8 @@
 package client
 
 import (
+	"context"
 	"fmt"
-	"net/http"
 	"net/url"
 )
 
@@ -22,23 +22,18 @@ type searchResponse struct {
 }
 
 // GetSearchResults returns search results for given query, offset and limit.
-func (c *Client) GetSearchResults(query string, offset, limit int) ([]SearchItem, int, error) {
-	req, err := c.getRequest("/search.json",
+func (c *Client) GetSearchResults(ctx context.Context, query string, offset, limit int) ([]SearchItem, int, error) {
+	req, err := c.getRequest(ctx, "/search.json",
 		fmt.Sprintf("q=%s&offset=%d&limit=%d", url.QueryEscape(query), offset, limit))
 	if err != nil {
 		return nil, 0, err
 	}
 
 	var response searchResponse
-	res, err := c.Do(req, &response)
+	_, err = c.Do(req, &response)
 	if err != nil {
 		return nil, 0, err
 	}
 
-	switch res.StatusCode {
-	case http.StatusOK:
-		return response.SearchItems, response.TotalCount, nil
-	default:
-		return nil, 0, fmt.Errorf("could not get search item (status %v)", res.StatusCode)
-	}
+	return response.SearchItems, response.TotalCount, nil
 }
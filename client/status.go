@@ -0,0 +1,55 @@
+package client
+
+import "context"
+
+// IssueStatus represents Redmine issue status (e.g. New, Resolved, Closed).
+type IssueStatus struct {
+	ID       int64  `json:"id"`
+	Name     string `json:"name"`
+	IsClosed bool   `json:"is_closed"`
+}
+
+// IssueStatuses represents a list of issue statuses.
+type IssueStatuses []IssueStatus
+
+type issueStatusesResponse struct {
+	IssueStatuses IssueStatuses `json:"issue_statuses"`
+}
+
+// GetIssueStatuses fetches all issue statuses available on Redmine server.
+func (c *Client) GetIssueStatuses(ctx context.Context) (IssueStatuses, error) {
+	req, err := c.getRequest(ctx, "/issue_statuses.json", "")
+	if err != nil {
+		return nil, err
+	}
+
+	var response issueStatusesResponse
+	_, err = c.Do(req, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.IssueStatuses, nil
+}
+
+// Valid checks whether status with provided name parameter exists. If yes,
+// returns its ID and true as second parameter; if not, return false as second parameter.
+func (sts IssueStatuses) Valid(name string) (int64, bool) {
+	for _, status := range sts {
+		if status.Name == name {
+			return status.ID, true
+		}
+	}
+
+	return 0, false
+}
+
+// Names returns status names.
+func (sts IssueStatuses) Names() []string {
+	names := make([]string, 0, len(sts))
+	for _, status := range sts {
+		names = append(names, status.Name)
+	}
+
+	return names
+}
@@ -1,14 +1,10 @@
 package client
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
 	"fmt"
-	"net/http"
 	"time"
-
-	"github.com/jedib0t/go-pretty/table"
-	"github.com/mightymatth/arcli/utils"
 )
 
 // TimeEntry represents Redmine time entry model.
@@ -25,63 +21,49 @@ type TimeEntry struct {
 	UpdatedOn time.Time `json:"updated_on"`
 }
 
-// PrintTable prints table in suitable format.
-func (te TimeEntry) PrintTable() {
-	t := utils.NewTable()
-	t.AppendHeader(table.Row{"Entry ID", "Project Name", "Issue ID", "Hours", "Activity", "Comment", "Spent On"})
-	t.AppendRow(table.Row{fmt.Sprint(te.ID), te.Project.Name, te.Issue.String(),
-		fmt.Sprint(te.Hours), te.Activity.Name, te.Comments, te.SpentOn.Format(DayDateFormat)})
-	t.Render()
-}
-
 type timeEntriesResponse struct {
 	TimeEntries []TimeEntry `json:"time_entries"`
+	pageEnvelope
+}
+
+func (r *timeEntriesResponse) items() []TimeEntry {
+	return r.TimeEntries
 }
 
 type timeEntryResponse struct {
 	TimeEntry TimeEntry `json:"time_entry"`
 }
 
-// GetTimeEntries fetches time entries for requested queryParams
-func (c *Client) GetTimeEntries(queryParams string) ([]TimeEntry, error) {
-	req, err := c.getRequest("/time_entries.json", queryParams)
-	if err != nil {
-		return nil, err
-	}
+// GetTimeEntries fetches all pages of time entries for requested queryParams.
+// If queryParams contain limit, it caps the total number of fetched entries.
+func (c *Client) GetTimeEntries(ctx context.Context, queryParams string) ([]TimeEntry, error) {
+	return fetchAll(ctx, c, "/time_entries.json", queryParams, newTimeEntriesPage)
+}
 
-	var response timeEntriesResponse
-	res, err := c.Do(req, &response)
-	if err != nil {
-		return nil, err
-	}
+// StreamTimeEntries fetches all pages of time entries for requested queryParams
+// and calls fn for each page as soon as it arrives, in order.
+func (c *Client) StreamTimeEntries(ctx context.Context, queryParams string, fn func([]TimeEntry) error) error {
+	return streamPages(ctx, c, "/time_entries.json", queryParams, newTimeEntriesPage, fn)
+}
 
-	switch res.StatusCode {
-	case http.StatusOK:
-		return response.TimeEntries, nil
-	default:
-		return nil, fmt.Errorf("cannot get time entries (status %v)", res.StatusCode)
-	}
+func newTimeEntriesPage() page[TimeEntry] {
+	return &timeEntriesResponse{}
 }
 
 // GetTimeEntry fetches time entry for given ID.
-func (c *Client) GetTimeEntry(id int) (*TimeEntry, error) {
-	req, err := c.getRequest(fmt.Sprintf("/time_entries/%d.json", id), "")
+func (c *Client) GetTimeEntry(ctx context.Context, id int) (*TimeEntry, error) {
+	req, err := c.getRequest(ctx, fmt.Sprintf("/time_entries/%d.json", id), "")
 	if err != nil {
 		return nil, err
 	}
 
 	var response timeEntryResponse
-	res, err := c.Do(req, &response)
+	_, err = c.Do(req, &response)
 	if err != nil {
 		return nil, err
 	}
 
-	switch res.StatusCode {
-	case http.StatusOK:
-		return &response.TimeEntry, nil
-	default:
-		return nil, fmt.Errorf("cannot get time entry (status %v)", res.StatusCode)
-	}
+	return &response.TimeEntry, nil
 }
 
 type timeEntryBody struct {
@@ -100,87 +82,41 @@ type TimeEntryPost struct {
 }
 
 // AddTimeEntry adds new time entry.
-func (c *Client) AddTimeEntry(entry TimeEntryPost) (*TimeEntry, error) {
-	req, err := c.postRequest("/time_entries.json", timeEntryBody{TimeEntry: entry})
+func (c *Client) AddTimeEntry(ctx context.Context, entry TimeEntryPost) (*TimeEntry, error) {
+	req, err := c.postRequest(ctx, "/time_entries.json", timeEntryBody{TimeEntry: entry})
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := c.HTTPClient.Do(req)
+	var response timeEntryResponse
+	_, err = c.Do(req, &response)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	switch resp.StatusCode {
-	case http.StatusCreated:
-		var teRes timeEntryResponse
-		err = json.NewDecoder(resp.Body).Decode(&teRes)
-		if err != nil {
-			return nil, err
-		}
-		return &teRes.TimeEntry, nil
-	case http.StatusUnprocessableEntity:
-		var errRes error422Response
-		err = json.NewDecoder(resp.Body).Decode(&errRes)
-		if err != nil {
-			return nil, err
-		}
-		return nil, fmt.Errorf(utils.PrintWithDelimiter(errRes.Errors))
-	default:
-		return nil, fmt.Errorf("status %v", resp.StatusCode)
-	}
+
+	return &response.TimeEntry, nil
 }
 
 // UpdateTimeEntry updates time entry.
-func (c *Client) UpdateTimeEntry(id int, entry TimeEntryPost) error {
-	req, err := c.putRequest(fmt.Sprintf("/time_entries/%d.json", id), timeEntryBody{TimeEntry: entry})
-	if err != nil {
-		return err
-	}
-
-	resp, err := c.HTTPClient.Do(req)
+func (c *Client) UpdateTimeEntry(ctx context.Context, id int, entry TimeEntryPost) error {
+	req, err := c.putRequest(ctx, fmt.Sprintf("/time_entries/%d.json", id), timeEntryBody{TimeEntry: entry})
 	if err != nil {
 		return err
 	}
-	defer resp.Body.Close()
 
-	switch resp.StatusCode {
-	case http.StatusOK:
-		return nil
-	case http.StatusUnprocessableEntity:
-		var errRes error422Response
-		err = json.NewDecoder(resp.Body).Decode(&errRes)
-		if err != nil {
-			return err
-		}
-		return fmt.Errorf(utils.PrintWithDelimiter(errRes.Errors))
-	default:
-		return fmt.Errorf("status %v", resp.StatusCode)
-	}
+	_, err = c.Do(req, nil)
+	return err
 }
 
 // DeleteTimeEntry deletes time entry with requested ID.
-func (c *Client) DeleteTimeEntry(id int) error {
-	req, err := c.deleteRequest(fmt.Sprintf("/time_entries/%v.json", id))
+func (c *Client) DeleteTimeEntry(ctx context.Context, id int) error {
+	req, err := c.deleteRequest(ctx, fmt.Sprintf("/time_entries/%v.json", id))
 	if err != nil {
 		return err
 	}
 
-	resp, err := c.HTTPClient.Do(req)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-
-	switch resp.StatusCode {
-	case http.StatusOK, http.StatusNoContent:
-		return nil
-	case http.StatusNotFound:
-		return fmt.Errorf("there is no time entry with id %v", id)
-	default:
-		return fmt.Errorf("status code %v", resp.StatusCode)
-	}
+	_, err = c.Do(req, nil)
+	return err
 }
 
 // DateTime custom representation of date.
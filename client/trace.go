@@ -0,0 +1,386 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// redacted replaces secrets in debug output and traces.
+const redacted = "********"
+
+// secretHeaders are request headers whose values are redacted.
+var secretHeaders = []string{"X-Redmine-API-Key", "Authorization", "Cookie", "Set-Cookie"}
+
+// apiKeyRegex matches API key in bodies of user responses.
+var apiKeyRegex = regexp.MustCompile(`("api_key"\s*:\s*")[^"]*(")`)
+
+// exchange is request made by transport and its response.
+type exchange struct {
+	req      *http.Request
+	reqBody  []byte
+	resp     *http.Response
+	respBody []byte
+	err      error
+	start    time.Time
+	duration time.Duration
+}
+
+// roundTrip makes request with base transport. Request and response bodies are
+// copied if withBodies is set, while the response body stays readable.
+func roundTrip(base http.RoundTripper, req *http.Request, withBodies bool) *exchange {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	ex := &exchange{req: req, start: time.Now()}
+	if withBodies && req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			ex.reqBody, _ = io.ReadAll(body)
+			_ = body.Close()
+		}
+	}
+
+	ex.resp, ex.err = base.RoundTrip(req)
+	if withBodies && ex.err == nil {
+		ex.respBody, ex.err = io.ReadAll(ex.resp.Body)
+		_ = ex.resp.Body.Close()
+		ex.resp.Body = io.NopCloser(bytes.NewReader(ex.respBody))
+		if ex.err != nil {
+			ex.resp = nil
+		}
+	}
+	ex.duration = time.Since(ex.start)
+
+	return ex
+}
+
+// DebugTransport writes method, URL, status, duration and headers of every
+// request and its response, with secrets redacted.
+type DebugTransport struct {
+	// Base makes the requests (http.DefaultTransport is used if not set).
+	Base http.RoundTripper
+	// Out is where requests are written (os.Stderr is used if not set).
+	Out io.Writer
+	// Bodies makes bodies of requests and responses written as well.
+	Bodies bool
+
+	mu sync.Mutex
+}
+
+// WithDebug makes client write every request to out, as described in
+// DebugTransport. Bodies of requests and responses are written if bodies is set.
+func WithDebug(out io.Writer, bodies bool) Option {
+	return func(c *Client) error {
+		c.HTTPClient.Transport = &DebugTransport{Base: c.HTTPClient.Transport, Out: out, Bodies: bodies}
+		return nil
+	}
+}
+
+// RoundTrip makes the request and writes it with its response.
+func (t *DebugTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ex := roundTrip(t.Base, req, t.Bodies)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--> %v %v\n", req.Method, redactURL(req.URL))
+	writeHeaders(&b, req.Header)
+	if len(ex.reqBody) != 0 {
+		fmt.Fprintf(&b, "\n%s\n\n", bytes.TrimSpace(redactBody(ex.reqBody)))
+	}
+	if ex.err != nil {
+		fmt.Fprintf(&b, "<-- %v (%v)\n", ex.err, ex.duration.Round(100*time.Microsecond))
+	} else {
+		fmt.Fprintf(&b, "<-- %v (%v)\n", ex.resp.Status, ex.duration.Round(100*time.Microsecond))
+		writeHeaders(&b, ex.resp.Header)
+		if len(ex.respBody) != 0 {
+			fmt.Fprintf(&b, "\n%s\n\n", bytes.TrimSpace(redactBody(ex.respBody)))
+		}
+	}
+
+	out := t.Out
+	if out == nil {
+		out = os.Stderr
+	}
+
+	// parallel requests are written one by one
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	_, _ = io.WriteString(out, b.String())
+
+	return ex.resp, ex.err
+}
+
+func writeHeaders(w io.Writer, header http.Header) {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		for _, value := range redactHeader(name, header[name]) {
+			fmt.Fprintf(w, "    %v: %v\n", name, value)
+		}
+	}
+}
+
+// HARRecorder records requests made by clients and their responses, which can be
+// written in HAR (HTTP Archive) format with secrets redacted. It can be shared by
+// several clients.
+type HARRecorder struct {
+	// Creator is the name and version of application that made requests.
+	Creator, CreatorVersion string
+
+	mu        sync.Mutex
+	exchanges []*exchange
+}
+
+// WithHARRecorder makes client record every request with given recorder.
+func WithHARRecorder(recorder *HARRecorder) Option {
+	return func(c *Client) error {
+		c.HTTPClient.Transport = &harTransport{base: c.HTTPClient.Transport, recorder: recorder}
+		return nil
+	}
+}
+
+type harTransport struct {
+	base     http.RoundTripper
+	recorder *HARRecorder
+}
+
+// RoundTrip makes the request and records it with its response.
+func (t *harTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ex := roundTrip(t.base, req, true)
+
+	t.recorder.mu.Lock()
+	defer t.recorder.mu.Unlock()
+	t.recorder.exchanges = append(t.recorder.exchanges, ex)
+
+	return ex.resp, ex.err
+}
+
+// WriteFile writes recorded requests to HAR file with given name.
+func (r *HARRecorder) WriteFile(name string) error {
+	file, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+
+	err = r.Write(file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+
+	return err
+}
+
+// Write writes recorded requests in HAR format.
+func (r *HARRecorder) Write(w io.Writer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	archive := har{Log: harLog{
+		Version: "1.2",
+		Creator: harCreator{Name: r.Creator, Version: r.CreatorVersion},
+		Entries: make([]harEntry, 0, len(r.exchanges)),
+	}}
+	for _, ex := range r.exchanges {
+		archive.Log.Entries = append(archive.Log.Entries, newHAREntry(ex))
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(archive)
+}
+
+type har struct {
+	Log harLog `json:"log"`
+}
+
+type harLog struct {
+	Version string     `json:"version"`
+	Creator harCreator `json:"creator"`
+	Entries []harEntry `json:"entries"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harEntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+	Comment         string      `json:"comment,omitempty"`
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	PostData    *harPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	Content     harContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+}
+
+type harTimings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+func newHAREntry(ex *exchange) harEntry {
+	millis := float64(ex.duration.Microseconds()) / 1000
+
+	entry := harEntry{
+		StartedDateTime: ex.start.Format(time.RFC3339Nano),
+		Time:            millis,
+		Request: harRequest{
+			Method:      ex.req.Method,
+			URL:         redactURL(ex.req.URL),
+			HTTPVersion: ex.req.Proto,
+			Cookies:     []harNameValue{},
+			Headers:     harHeaders(ex.req.Header),
+			QueryString: harQuery(ex.req.URL),
+			HeadersSize: -1,
+			BodySize:    len(ex.reqBody),
+		},
+		Response: harResponse{
+			Cookies:     []harNameValue{},
+			Headers:     []harNameValue{},
+			HeadersSize: -1,
+		},
+		Timings: harTimings{Wait: millis},
+	}
+	if len(ex.reqBody) != 0 {
+		entry.Request.PostData = &harPostData{
+			MimeType: ex.req.Header.Get("Content-Type"),
+			Text:     string(redactBody(ex.reqBody)),
+		}
+	}
+
+	if ex.err != nil {
+		entry.Comment = ex.err.Error()
+		return entry
+	}
+
+	mimeType := ex.resp.Header.Get("Content-Type")
+	if mediaType, _, err := mime.ParseMediaType(mimeType); err == nil {
+		mimeType = mediaType
+	}
+
+	entry.Response.Status = ex.resp.StatusCode
+	entry.Response.StatusText = strings.TrimSpace(strings.TrimPrefix(ex.resp.Status, fmt.Sprint(ex.resp.StatusCode)))
+	entry.Response.HTTPVersion = ex.resp.Proto
+	entry.Response.Headers = harHeaders(ex.resp.Header)
+	entry.Response.Content = harContent{
+		Size:     len(ex.respBody),
+		MimeType: mimeType,
+		Text:     string(redactBody(ex.respBody)),
+	}
+	entry.Response.BodySize = len(ex.respBody)
+
+	return entry
+}
+
+func harHeaders(header http.Header) []harNameValue {
+	headers := []harNameValue{}
+	for name, values := range header {
+		for _, value := range redactHeader(name, values) {
+			headers = append(headers, harNameValue{Name: name, Value: value})
+		}
+	}
+	sort.SliceStable(headers, func(i, j int) bool { return headers[i].Name < headers[j].Name })
+
+	return headers
+}
+
+func harQuery(u *url.URL) []harNameValue {
+	query := []harNameValue{}
+	for name, values := range u.Query() {
+		for _, value := range values {
+			if name == "key" {
+				value = redacted
+			}
+			query = append(query, harNameValue{Name: name, Value: value})
+		}
+	}
+	sort.SliceStable(query, func(i, j int) bool { return query[i].Name < query[j].Name })
+
+	return query
+}
+
+func redactHeader(name string, values []string) []string {
+	for _, secret := range secretHeaders {
+		if strings.EqualFold(name, secret) {
+			redactedValues := make([]string, len(values))
+			for i := range redactedValues {
+				redactedValues[i] = redacted
+			}
+			return redactedValues
+		}
+	}
+
+	return values
+}
+
+// redactURL returns URL without password and API key given as 'key' parameter.
+func redactURL(u *url.URL) string {
+	redactedURL := *u
+	query := u.Query()
+	if query.Has("key") {
+		query.Set("key", redacted)
+		redactedURL.RawQuery = query.Encode()
+	}
+
+	return redactedURL.Redacted()
+}
+
+func redactBody(body []byte) []byte {
+	return apiKeyRegex.ReplaceAll(body, []byte("${1}"+redacted+"${2}"))
+}
@@ -0,0 +1,54 @@
+package client
+
+import "context"
+
+// Tracker represents Redmine issue tracker (e.g. Bug, Feature, Support).
+type Tracker struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+// Trackers represents a list of trackers.
+type Trackers []Tracker
+
+type trackersResponse struct {
+	Trackers Trackers `json:"trackers"`
+}
+
+// GetTrackers fetches all trackers available on Redmine server.
+func (c *Client) GetTrackers(ctx context.Context) (Trackers, error) {
+	req, err := c.getRequest(ctx, "/trackers.json", "")
+	if err != nil {
+		return nil, err
+	}
+
+	var response trackersResponse
+	_, err = c.Do(req, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Trackers, nil
+}
+
+// Valid checks whether tracker with provided name parameter exists. If yes,
+// returns its ID and true as second parameter; if not, return false as second parameter.
+func (trs Trackers) Valid(name string) (int64, bool) {
+	for _, tracker := range trs {
+		if tracker.Name == name {
+			return tracker.ID, true
+		}
+	}
+
+	return 0, false
+}
+
+// Names returns tracker names.
+func (trs Trackers) Names() []string {
+	names := make([]string, 0, len(trs))
+	for _, tracker := range trs {
+		names = append(names, tracker.Name)
+	}
+
+	return names
+}
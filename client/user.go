@@ -2,12 +2,9 @@ package client
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/url"
-
-	"github.com/mightymatth/arcli/config"
-
-	"github.com/spf13/viper"
 )
 
 // User represents user model in Redmine.
@@ -28,14 +25,17 @@ type UserAPIResponse struct {
 // NewAuthRequest fetches user credentials for given username and password. Method uses
 // simple basic authentication.
 func (c *Client) NewAuthRequest(ctx context.Context, username, password string) (*http.Request, error) {
-	u, err := url.Parse(viper.GetString(config.Host))
+	if c.BaseURL == "" {
+		return nil, ErrMissingCredentials
+	}
+
+	u, err := url.Parse(c.BaseURL)
 	if err != nil {
 		return nil, err
 	}
 
 	u.Path = "/users/current.json"
 	u.User = url.UserPassword(username, password)
-	c.setTransport()
 
 	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
 	if err != nil {
@@ -48,15 +48,9 @@ func (c *Client) NewAuthRequest(ctx context.Context, username, password string)
 	return req, nil
 }
 
-func (c *Client) setTransport() {
-	if transport := getTransport(); transport != nil {
-		c.HTTPClient.Transport = transport
-	}
-}
-
 // GetUser fetches data of currently logged user.
-func (c *Client) GetUser() (*User, error) {
-	req, err := c.getRequest("/users/current.json", "")
+func (c *Client) GetUser(ctx context.Context) (*User, error) {
+	req, err := c.getRequest(ctx, "/users/current.json", "")
 	if err != nil {
 		return nil, err
 	}
@@ -69,3 +63,33 @@ func (c *Client) GetUser() (*User, error) {
 
 	return &(userResponse.User), nil
 }
+
+type usersResponse struct {
+	Users []User `json:"users"`
+}
+
+// GetUserByLogin fetches user with given login. Redmine allows listing users
+// only to administrators.
+func (c *Client) GetUserByLogin(ctx context.Context, login string) (*User, error) {
+	req, err := c.getRequest(ctx, "/users.json", fmt.Sprintf("name=%s", url.QueryEscape(login)))
+	if err != nil {
+		return nil, err
+	}
+
+	var response usersResponse
+	_, err = c.Do(req, &response)
+	if IsForbidden(err) {
+		return nil, fmt.Errorf("only administrators can find users by login (use user ID instead): %w", err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, user := range response.Users {
+		if user.Username == login {
+			return &user, nil
+		}
+	}
+
+	return nil, fmt.Errorf("there is no user with login '%v'", login)
+}
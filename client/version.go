@@ -0,0 +1,58 @@
+package client
+
+import (
+	"context"
+	"fmt"
+)
+
+// Version represents Redmine project version (target version of issues).
+type Version struct {
+	ID     int64  `json:"id"`
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+// Versions represents a list of versions.
+type Versions []Version
+
+type versionsResponse struct {
+	Versions Versions `json:"versions"`
+}
+
+// GetProjectVersions fetches all versions available to project with requested ID.
+func (c *Client) GetProjectVersions(ctx context.Context, projectID int64) (Versions, error) {
+	req, err := c.getRequest(ctx, fmt.Sprintf("/projects/%v/versions.json", projectID), "")
+	if err != nil {
+		return nil, err
+	}
+
+	var response versionsResponse
+	_, err = c.Do(req, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Versions, nil
+}
+
+// Valid checks whether version with provided name parameter exists. If yes,
+// returns its ID and true as second parameter; if not, return false as second parameter.
+func (vs Versions) Valid(name string) (int64, bool) {
+	for _, version := range vs {
+		if version.Name == name {
+			return version.ID, true
+		}
+	}
+
+	return 0, false
+}
+
+// Names returns version names.
+func (vs Versions) Names() []string {
+	names := make([]string, 0, len(vs))
+	for _, version := range vs {
+		names = append(names, version.Name)
+	}
+
+	return names
+}
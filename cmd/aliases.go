@@ -8,7 +8,6 @@ import (
 	"github.com/mightymatth/arcli/config"
 
 	"github.com/jedib0t/go-pretty/table"
-	"github.com/mightymatth/arcli/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -80,19 +79,18 @@ func newAliasesDeleteCmd() *cobra.Command {
 
 func drawAliases() {
 	aliases := config.GetAliases()
-	if len(aliases) == 0 {
+	if len(aliases) == 0 && out.IsTable() {
 		fmt.Println("You have no previously aliases set.")
 		fmt.Printf("These can be set with: '%v'\n", newAliasesAddCmd().UseLine())
 		return
 	}
 
-	t := utils.NewTable()
-	t.AppendHeader(table.Row{"Alias", "ID"})
-	for key, val := range aliases {
-		t.AppendRow(table.Row{key, val})
+	rows := make([]table.Row, 0, len(aliases))
+	for _, key := range sortedKeys(aliases) {
+		rows = append(rows, table.Row{key, aliases[key]})
 	}
 
-	t.Render()
+	render(aliases, table.Row{"Alias", "ID"}, rows)
 }
 
 func validAliasesAddArgs() cobra.PositionalArgs {
@@ -2,12 +2,9 @@ package cmd
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"io"
 	"log"
-	"net/http"
-	"net/url"
 	"os"
 	"path/filepath"
 	"time"
@@ -52,12 +49,12 @@ func newLoginInlineCmd() *cobra.Command {
 		Run:     loginFunc,
 	}
 
-	c.Flags().StringVarP(&host, "server", "s", "", "Host of Redmine server (e.g. https://host.redmine.org)")
+	c.Flags().StringVarP(&host, "server", "s", "",
+		"Host of Redmine server (e.g. https://host.redmine.org; default host of the profile)")
 	c.Flags().StringVarP(&username, "username", "u", "", "Username")
 	c.Flags().StringVarP(&password, "password", "p", "", "Password")
-	c.Flags().StringVarP(&caCert, "cacert", "c", "", "CA Certificate")
+	c.Flags().StringVarP(&caCert, "cacert", "c", "", "CA Certificate (default CA certificate of the profile)")
 
-	_ = c.MarkFlagRequired("server")
 	_ = c.MarkFlagRequired("username")
 	_ = c.MarkFlagRequired("password")
 
@@ -74,57 +71,68 @@ func newLogoutCmd() *cobra.Command {
 	}
 }
 
-func loginFunc(_ *cobra.Command, _ []string) {
-	authCtx, cancel := context.WithTimeout(context.Background(), 8*time.Second)
+func loginFunc(cmd *cobra.Command, _ []string) {
+	authCtx, cancel := context.WithTimeout(ctx, 8*time.Second)
 	defer cancel()
 
-	viper.Set(config.Host, host)
-
-	if caCert != "" {
-		caCertAbsPath, err := filepath.Abs(caCert)
-		if err != nil {
-			log.Fatalf("Cannot fetch certificate absolute path: %v\n", err)
+	if host == "" {
+		host = viper.GetString(config.Key(config.Host))
+	}
+	if host == "" {
+		fmt.Println("Provide host of Redmine server with --server flag.")
+		return
+	}
+	viper.Set(config.Key(config.Host), host)
+
+	// CA certificate stored in profile is kept, unless another one is given
+	if cmd.Flags().Changed("cacert") {
+		caCertAbsPath := caCert
+		if caCert != "" {
+			var err error
+			caCertAbsPath, err = filepath.Abs(caCert)
+			if err != nil {
+				fmt.Println("Cannot fetch certificate absolute path:", err)
+				return
+			}
 		}
 
-		viper.Set(config.CaCert, caCertAbsPath)
-	} else {
-		viper.Set(config.CaCert, "")
+		viper.Set(config.Key(config.CaCert), caCertAbsPath)
 	}
 
-	req, ReqErr := RClient.NewAuthRequest(authCtx, username, password)
-	if ReqErr != nil {
-		log.Println("User request:", ReqErr)
+	loginClient, err := newClient(host, viper.GetString(config.Key(config.CaCert)))
+	if err != nil {
+		fmt.Println("Cannot create Redmine client:", err)
+		return
 	}
 
-	var userAPIResponse *client.UserAPIResponse
-	res, ResErr := RClient.Do(req, &userAPIResponse)
-	var urlError *url.Error
-	if errors.As(ResErr, &urlError) {
-		fmt.Println("User response:", ResErr)
+	req, err := loginClient.NewAuthRequest(authCtx, username, password)
+	if err != nil {
+		fmt.Println("Cannot create login request:", err)
 		return
 	}
 
-	switch res.StatusCode {
-	case http.StatusOK:
-		break
-	case http.StatusUnauthorized:
+	var userAPIResponse *client.UserAPIResponse
+	_, err = loginClient.Do(req, &userAPIResponse)
+	if client.IsUnauthorized(err) {
 		fmt.Println("Wrong login credentials!")
 		return
-	default:
-		fmt.Println(fmt.Sprintf("Cannot login user (%v)", res.StatusCode))
+	}
+	if err != nil {
+		fmt.Println("Cannot login user:", err)
 		return
 	}
 
 	user := userAPIResponse.User
-	viper.Set(config.APIKey, user.APIKey)
-	viper.Set(config.UserID, user.ID)
-	err := viper.WriteConfig()
+	viper.Set(config.Key(config.APIKey), user.APIKey)
+	viper.Set(config.Key(config.UserID), user.ID)
+	err = viper.WriteConfig()
 
 	if err != nil {
-		log.Fatalln("Unable to save config:", err)
+		fmt.Println("Unable to save config:", err)
+		return
 	}
 
-	fmt.Println("You have successfully logged in!")
+	fmt.Printf("You have successfully logged in (profile '%v')!\n", config.ActiveProfile())
 }
 
 func interactiveLoginInputFunc(_ *cobra.Command, _ []string) {
@@ -156,7 +164,7 @@ func interactiveLoginInputFunc(_ *cobra.Command, _ []string) {
 }
 
 func askForHost(t *terminal.Terminal) (string, bool) {
-	previousHost := viper.GetString(config.Host)
+	previousHost := viper.GetString(config.Key(config.Host))
 	var prefix string
 	if previousHost != "" {
 		prefix = fmt.Sprintf("Host (%s): ", previousHost)
@@ -194,9 +202,9 @@ func askForText(t *terminal.Terminal, prefix string, hidden bool) (string, bool)
 }
 
 func logoutFunc(_ *cobra.Command, _ []string) {
-	viper.Set(config.APIKey, "")
-	viper.Set(config.UserID, "")
-	viper.Set(config.CaCert, "")
+	viper.Set(config.Key(config.APIKey), "")
+	viper.Set(config.Key(config.UserID), "")
+	viper.Set(config.Key(config.CaCert), "")
 	err := viper.WriteConfig()
 
 	if err != nil {
@@ -0,0 +1,272 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/mightymatth/arcli/client"
+	"github.com/mightymatth/arcli/config"
+	"gopkg.in/yaml.v3"
+)
+
+// workCalendar represents hours expected to be logged on every day, with
+// public holidays and personal leave days off.
+type workCalendar struct {
+	targets  workTargets
+	holidays holidays
+	leave    map[string]string
+}
+
+// loadWorkCalendar reads targets, holidays file and leave days from config.
+func loadWorkCalendar() (workCalendar, error) {
+	targets, err := loadWorkTargets()
+	if err != nil {
+		return workCalendar{}, err
+	}
+
+	calendar := workCalendar{targets: targets, leave: config.GetLeaveDays()}
+
+	file := config.Defaults()[string(config.Holidays)]
+	if file != "" {
+		calendar.holidays, err = readHolidays(file)
+		if err != nil {
+			return workCalendar{}, fmt.Errorf("cannot read holidays file: %v", err)
+		}
+	}
+
+	return calendar, nil
+}
+
+// Expected returns hours expected to be logged on day. If none are expected,
+// the reason is returned as well.
+func (c workCalendar) Expected(day time.Time) (float64, string) {
+	if note, found := c.leave[day.Format(client.DateTimeFormat)]; found {
+		return 0, strings.TrimSpace("leave " + note)
+	}
+	if name, found := c.holidays.Name(day); found {
+		return 0, strings.TrimSpace("holiday " + name)
+	}
+
+	hours := c.targets.hours[day.Weekday()]
+	if hours == 0 {
+		return 0, "no target"
+	}
+
+	return hours, ""
+}
+
+// ExpectedPeriod returns hours expected to be logged in period.
+func (c workCalendar) ExpectedPeriod(p period) float64 {
+	var hours float64
+	for day := p.From; !day.After(p.To); day = day.AddDate(0, 0, 1) {
+		expected, _ := c.Expected(day)
+		hours += expected
+	}
+
+	return hours
+}
+
+// holidays represents public holidays, keyed by date ('2006-01-02') or by
+// month and day ('01-02') for the ones repeating every year.
+type holidays struct {
+	dates  map[string]string
+	yearly map[string]string
+}
+
+// Name returns the name of holiday on day.
+func (h holidays) Name(day time.Time) (string, bool) {
+	if name, found := h.dates[day.Format(client.DateTimeFormat)]; found {
+		return name, true
+	}
+
+	name, found := h.yearly[day.Format("01-02")]
+	return name, found
+}
+
+func (h *holidays) add(day time.Time, name string) {
+	if h.dates == nil {
+		h.dates = make(map[string]string)
+	}
+	h.dates[day.Format(client.DateTimeFormat)] = name
+}
+
+// expandHome replaces '~/' at the beginning of file path with user home directory.
+func expandHome(file string) (string, error) {
+	if !strings.HasPrefix(file, "~/") {
+		return file, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(home, file[2:]), nil
+}
+
+// holidaysPath returns absolute path of holidays file, so that it is found
+// regardless of the directory arcli is run from.
+func holidaysPath(file string) (string, error) {
+	file, err := expandHome(file)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Abs(file)
+}
+
+// readHolidays reads holidays from ICS file, or YAML file with list of dates,
+// list of objects with date and name, or map of dates to names.
+func readHolidays(file string) (holidays, error) {
+	file, err := expandHome(file)
+	if err != nil {
+		return holidays{}, err
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return holidays{}, err
+	}
+
+	if strings.EqualFold(filepath.Ext(file), ".ics") {
+		return parseICSHolidays(data)
+	}
+
+	return parseYAMLHolidays(data)
+}
+
+func parseYAMLHolidays(data []byte) (holidays, error) {
+	var h holidays
+
+	var doc yaml.Node
+	err := yaml.Unmarshal(data, &doc)
+	if err != nil {
+		return h, err
+	}
+	if len(doc.Content) == 0 {
+		return h, nil
+	}
+
+	add := func(date, name string) error {
+		day, err := time.Parse(client.DateTimeFormat, date)
+		if err != nil {
+			return fmt.Errorf("invalid holiday date '%v'", date)
+		}
+		h.add(day, name)
+		return nil
+	}
+
+	root := doc.Content[0]
+	switch root.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(root.Content); i += 2 {
+			if err = add(root.Content[i].Value, root.Content[i+1].Value); err != nil {
+				return h, err
+			}
+		}
+	case yaml.SequenceNode:
+		for _, item := range root.Content {
+			if item.Kind == yaml.ScalarNode {
+				if err = add(item.Value, ""); err != nil {
+					return h, err
+				}
+				continue
+			}
+
+			var holiday struct {
+				Date string `yaml:"date"`
+				Name string `yaml:"name"`
+			}
+			if err = item.Decode(&holiday); err != nil {
+				return h, err
+			}
+			if err = add(holiday.Date, holiday.Name); err != nil {
+				return h, err
+			}
+		}
+	default:
+		return h, fmt.Errorf("holidays must be list of dates or map of dates to names")
+	}
+
+	return h, nil
+}
+
+// parseICSHolidays reads all-day events from iCalendar data. Events repeating
+// yearly are supported, other recurrence rules are ignored.
+func parseICSHolidays(data []byte) (holidays, error) {
+	var h holidays
+
+	var lines []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		// long lines are folded, with continuation starting with whitespace
+		if len(lines) != 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return h, err
+	}
+
+	var inEvent, yearly bool
+	var start, end time.Time
+	var summary string
+	for _, line := range lines {
+		name, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		name, _, _ = strings.Cut(strings.ToUpper(name), ";")
+
+		switch {
+		case name == "BEGIN" && value == "VEVENT":
+			inEvent, yearly = true, false
+			start, end, summary = time.Time{}, time.Time{}, ""
+		case !inEvent:
+		case name == "DTSTART":
+			start, _ = time.Parse("20060102", firstN(value, 8))
+		case name == "DTEND":
+			end, _ = time.Parse("20060102", firstN(value, 8))
+		case name == "SUMMARY":
+			summary = strings.ReplaceAll(value, `\,`, ",")
+		case name == "RRULE":
+			yearly = strings.Contains(strings.ToUpper(value), "FREQ=YEARLY")
+		case name == "END" && value == "VEVENT":
+			inEvent = false
+			if start.IsZero() {
+				continue
+			}
+			// end date of all-day events is exclusive
+			if !end.After(start) {
+				end = start.AddDate(0, 0, 1)
+			}
+			for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+				if yearly {
+					if h.yearly == nil {
+						h.yearly = make(map[string]string)
+					}
+					h.yearly[day.Format("01-02")] = summary
+					continue
+				}
+				h.add(day, summary)
+			}
+		}
+	}
+
+	return h, nil
+}
+
+func firstN(s string, n int) string {
+	if len(s) < n {
+		return s
+	}
+	return s[:n]
+}
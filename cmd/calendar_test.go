@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestHolidaysPath(t *testing.T) {
+	home, dir := t.TempDir(), t.TempDir()
+	t.Setenv("HOME", home)
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+	if err = os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	// temporary directory may be a symbolic link
+	dir, _ = os.Getwd()
+
+	tests := []struct {
+		file string
+		want string
+	}{
+		{"~/holidays.csv", filepath.Join(home, "holidays.csv")},
+		{"~/cal/holidays.ics", filepath.Join(home, "cal", "holidays.ics")},
+		{"holidays.ics", filepath.Join(dir, "holidays.ics")},
+		{"./cal/../holidays.ics", filepath.Join(dir, "holidays.ics")},
+		{"~holidays.ics", filepath.Join(dir, "~holidays.ics")},
+		{"/etc/holidays.yaml", "/etc/holidays.yaml"},
+	}
+
+	for _, tt := range tests {
+		got, err := holidaysPath(tt.file)
+		if err != nil {
+			t.Errorf("holidaysPath(%q) returned error: %v", tt.file, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("holidaysPath(%q) = %v, want %v", tt.file, got, tt.want)
+		}
+	}
+}
+
+func TestReadHolidaysHome(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	err := os.WriteFile(filepath.Join(home, "holidays.yaml"), []byte("- 2026-12-25\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path, err := holidaysPath("~/holidays.yaml")
+	if err != nil {
+		t.Fatalf("holidaysPath() returned error: %v", err)
+	}
+
+	for _, file := range []string{"~/holidays.yaml", path} {
+		h, err := readHolidays(file)
+		if err != nil {
+			t.Errorf("readHolidays(%q) returned error: %v", file, err)
+			continue
+		}
+		if _, found := h.dates["2026-12-25"]; !found || len(h.dates) != 1 {
+			t.Errorf("readHolidays(%q) = %v, want 2026-12-25", file, h.dates)
+		}
+	}
+}
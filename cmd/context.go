@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mightymatth/arcli/git"
+	"gopkg.in/yaml.v3"
+)
+
+// contextFileName is the name of file in working tree that sets the current issue.
+const contextFileName = ".arcli"
+
+// contextIssueID is ID of the current issue, resolved by validIssueArgs when
+// issue argument is omitted.
+var contextIssueID int64
+
+// issueArgID returns ID of issue given as the first argument, or of the current
+// issue if the argument is omitted.
+func issueArgID(args []string) int64 {
+	if len(args) == 0 {
+		return contextIssueID
+	}
+
+	id, _ := resolveIssueID(args[0])
+	return id
+}
+
+// currentIssue returns ID of issue in the name of checked out git branch, or
+// set in .arcli file in working directory or its parents. Source of the issue
+// is returned as well.
+func currentIssue() (int64, string, error) {
+	if branch, err := git.CurrentBranch("."); err == nil {
+		pattern, err := branchPattern()
+		if err != nil {
+			return 0, "", err
+		}
+		if id, found := branchIssueID(branch, pattern); found {
+			return id, fmt.Sprintf("git branch '%v'", branch), nil
+		}
+	}
+
+	file, found := findContextFile()
+	if !found {
+		return 0, "", fmt.Errorf("provide issue id, or use it in git branch name (e.g. 'feature/20123-foo') "+
+			"or in %v file (e.g. 'issue: 20123')", contextFileName)
+	}
+
+	id, err := readContextFile(file)
+	if err != nil {
+		return 0, "", fmt.Errorf("cannot read issue from %v: %v", file, err)
+	}
+
+	return id, file, nil
+}
+
+// findContextFile looks for .arcli file in working directory and its parents.
+func findContextFile() (string, bool) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", false
+	}
+
+	for {
+		file := filepath.Join(dir, contextFileName)
+		if info, err := os.Stat(file); err == nil && !info.IsDir() {
+			return file, true
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// readContextFile reads issue ID or alias from .arcli file, given either as
+// 'issue' key in YAML or as the only content of file.
+func readContextFile(file string) (int64, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return 0, err
+	}
+
+	var context struct {
+		Issue string `yaml:"issue"`
+	}
+	issue := strings.TrimSpace(string(data))
+	if yaml.Unmarshal(data, &context) == nil && context.Issue != "" {
+		issue = context.Issue
+	}
+
+	return resolveIssueID(strings.TrimPrefix(issue, "#"))
+}
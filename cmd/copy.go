@@ -0,0 +1,308 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jedib0t/go-pretty/table"
+	"github.com/mightymatth/arcli/client"
+	"github.com/mightymatth/arcli/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	copyTo      string
+	copyYes     bool
+	repeatEvery string
+	repeatFrom  string
+	repeatTo    string
+)
+
+// plannedEntry represents time entry that is going to be created from existing one.
+type plannedEntry struct {
+	SourceID int64                `json:"source_id"`
+	Entry    client.TimeEntryPost `json:"entry"`
+	Exists   bool                 `json:"exists"`
+
+	source client.TimeEntry
+}
+
+func newTimeEntriesCopyCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:     "copy [id...]",
+		Aliases: []string{"cp", "clone"},
+		Args:    validTimeEntryArgs(),
+		Short:   "Copy time entries to another date",
+		Example: `arcli log copy 1234 1235 --to today`,
+		Run:     timeEntriesCopyFunc,
+	}
+
+	c.Flags().StringVar(&copyTo, "to", "today",
+		"The date time entries are copied to ('today', 'yesterday', '2020-01-15')")
+	c.Flags().BoolVarP(&copyYes, "yes", "y", false,
+		"Create time entries without confirmation")
+
+	return c
+}
+
+func timeEntriesCopyFunc(_ *cobra.Command, args []string) {
+	to, err := spentOnParse(copyTo)
+	if err != nil {
+		fmt.Printf("Cannot parse date value: %v\n", err)
+		return
+	}
+
+	sources, err := getTimeEntries(args)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	planned := make([]plannedEntry, 0, len(sources))
+	for _, source := range sources {
+		planned = append(planned, planEntry(source, *to))
+	}
+
+	createPlannedEntries(planned, *to, *to)
+}
+
+func newTimeEntriesRepeatCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:     "repeat [id]",
+		Aliases: []string{"series"},
+		Args:    cobra.MatchAll(cobra.ExactArgs(1), validTimeEntryArgs()),
+		Short:   "Repeat time entry on a series of days",
+		Long: `Repeat time entry on a series of days. Days can be 'day' (every day), 'weekday'
+(Monday to Friday), 'workday' (days with target hours that are not holidays or leave days),
+'week' (the same weekday as the time entry) or comma separated weekday names.`,
+		Example: `arcli log repeat 1234 --every weekday --from 2026-10-19 --to 2026-10-30
+arcli log repeat 1234 --every monday,thursday --to 2026-12-31`,
+		Run: timeEntriesRepeatFunc,
+	}
+
+	c.Flags().StringVar(&repeatEvery, "every", "weekday",
+		"Days to repeat time entry on ('day', 'weekday', 'workday', 'week', 'monday,friday')")
+	c.Flags().StringVar(&repeatFrom, "from", "today",
+		"The first day of series ('today', 'yesterday', '2020-01-15')")
+	c.Flags().StringVar(&repeatTo, "to", "",
+		"The last day of series ('today', 'yesterday', '2020-01-15')")
+	c.Flags().BoolVarP(&copyYes, "yes", "y", false,
+		"Create time entries without confirmation")
+	_ = c.MarkFlagRequired("to")
+
+	return c
+}
+
+func timeEntriesRepeatFunc(_ *cobra.Command, args []string) {
+	from, err := spentOnParse(repeatFrom)
+	if err != nil {
+		fmt.Printf("Cannot parse from date: %v\n", err)
+		return
+	}
+	to, err := spentOnParse(repeatTo)
+	if err != nil {
+		fmt.Printf("Cannot parse to date: %v\n", err)
+		return
+	}
+	if to.Before(*from) {
+		fmt.Println("The last day of series is before the first one")
+		return
+	}
+
+	sources, err := getTimeEntries(args)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	source := sources[0]
+
+	matches, err := repeatDays(repeatEvery, source.SpentOn.Time)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	var planned []plannedEntry
+	for day := *from; !day.After(*to); day = day.AddDate(0, 0, 1) {
+		if matches(day) {
+			planned = append(planned, planEntry(source, day))
+		}
+	}
+	if len(planned) == 0 {
+		fmt.Println("There are no days in series.")
+		return
+	}
+
+	createPlannedEntries(planned, *from, *to)
+}
+
+// repeatDays returns function that reports whether time entry should be repeated
+// on given day.
+func repeatDays(every string, sourceDay time.Time) (func(time.Time) bool, error) {
+	switch strings.ToLower(every) {
+	case "day", "daily":
+		return func(time.Time) bool { return true }, nil
+	case "weekday", "weekdays":
+		return func(day time.Time) bool {
+			return day.Weekday() != time.Saturday && day.Weekday() != time.Sunday
+		}, nil
+	case "workday", "workdays":
+		calendar, err := loadWorkCalendar()
+		if err != nil {
+			return nil, err
+		}
+		if !calendar.targets.set {
+			return nil, fmt.Errorf("set targets in defaults to repeat on workdays (e.g. dailytarget)")
+		}
+		return func(day time.Time) bool {
+			expected, _ := calendar.Expected(day)
+			return expected > 0
+		}, nil
+	case "week", "weekly":
+		return func(day time.Time) bool { return day.Weekday() == sourceDay.Weekday() }, nil
+	}
+
+	var weekdays [7]bool
+	for _, name := range strings.Split(every, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+
+		found := false
+		for day := time.Sunday; day <= time.Saturday; day++ {
+			dayName := strings.ToLower(day.String())
+			if name == dayName || name == dayName[:3] {
+				weekdays[day] = true
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("invalid days '%v' (use 'day', 'weekday', 'workday', "+
+				"'week' or weekday names such as 'monday,friday')", every)
+		}
+	}
+
+	return func(day time.Time) bool { return weekdays[day.Weekday()] }, nil
+}
+
+func getTimeEntries(ids []string) ([]client.TimeEntry, error) {
+	entries := make([]client.TimeEntry, 0, len(ids))
+	for _, arg := range ids {
+		id, _ := strconv.Atoi(arg)
+		entry, err := RClient.GetTimeEntry(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get time entry: %v", describeError(err, fmt.Sprintf("time entry %v", id)))
+		}
+		entries = append(entries, *entry)
+	}
+
+	return entries, nil
+}
+
+func planEntry(source client.TimeEntry, day time.Time) plannedEntry {
+	entry := client.TimeEntryPost{
+		IssueID:    int(source.Issue.ID),
+		SpentOn:    *client.NewDateTime(day),
+		Hours:      float32(source.Hours),
+		ActivityID: int(source.Activity.ID),
+		Comments:   source.Comments,
+	}
+	if source.Issue.ID == 0 {
+		entry.ProjectID = int(source.Project.ID)
+	}
+
+	return plannedEntry{SourceID: source.ID, Entry: entry, source: source}
+}
+
+// entryIdentity returns values that make time entries identical.
+func entryIdentity(day time.Time, issueID, projectID, activityID int64, hours float64, comments string) string {
+	if issueID != 0 {
+		projectID = 0
+	}
+
+	return fmt.Sprintf("%v|%v|%v|%v|%.2f|%v", day.Format(client.DateTimeFormat), issueID, projectID,
+		activityID, hours, strings.TrimSpace(comments))
+}
+
+// createPlannedEntries marks planned entries that already exist between from and
+// to dates, shows preview and creates the rest of them after confirmation.
+func createPlannedEntries(planned []plannedEntry, from, to time.Time) {
+	existing, err := RClient.GetTimeEntries(ctx, fmt.Sprintf("from=%v&to=%v&user_id=me",
+		from.Format(client.DateTimeFormat), to.Format(client.DateTimeFormat)))
+	if err != nil {
+		fmt.Println("Cannot get existing time entries:", err)
+		return
+	}
+
+	identities := make(map[string]bool, len(existing))
+	for _, entry := range existing {
+		identities[entryIdentity(entry.SpentOn.Time, entry.Issue.ID, entry.Project.ID,
+			entry.Activity.ID, entry.Hours, entry.Comments)] = true
+	}
+
+	sort.SliceStable(planned, func(i, j int) bool {
+		return planned[i].Entry.SpentOn.Before(planned[j].Entry.SpentOn.Time)
+	})
+
+	count := 0
+	for i, p := range planned {
+		identity := entryIdentity(p.Entry.SpentOn.Time, int64(p.Entry.IssueID), p.source.Project.ID,
+			int64(p.Entry.ActivityID), float64(p.Entry.Hours), p.Entry.Comments)
+		planned[i].Exists = identities[identity]
+		// the same entry can be planned twice (e.g. copying two identical entries)
+		identities[identity] = true
+
+		if !planned[i].Exists {
+			count++
+		}
+	}
+
+	drawPlannedEntries(planned)
+	if count == 0 {
+		if out.IsTable() {
+			fmt.Println("All time entries already exist, nothing to create.")
+		}
+		return
+	}
+
+	if !copyYes && !utils.Confirm(fmt.Sprintf("Create %v time entries?", count)) {
+		return
+	}
+
+	created := 0
+	for _, p := range planned {
+		if p.Exists {
+			continue
+		}
+
+		entry, err := RClient.AddTimeEntry(ctx, p.Entry)
+		if err != nil {
+			fmt.Printf("Cannot create time entry on %v: %v\n", p.Entry.SpentOn.Format(client.DayDateFormat), err)
+			continue
+		}
+		created++
+		if out.IsTable() {
+			fmt.Printf("Time entry %v created on %v.\n", entry.ID, entry.SpentOn.Format(client.DayDateFormat))
+		}
+	}
+
+	if out.IsTable() {
+		fmt.Printf("%v of %v time entries created.\n", created, count)
+	}
+}
+
+func drawPlannedEntries(planned []plannedEntry) {
+	rows := make([]table.Row, 0, len(planned))
+	for _, p := range planned {
+		status := "new"
+		if p.Exists {
+			status = "skipped (exists)"
+		}
+
+		rows = append(rows, table.Row{p.Entry.SpentOn.Format(client.DayDateFormat), p.source.Project.Name,
+			p.source.Issue.String(), p.source.Activity.Name, p.source.Hours, p.source.Comments, status})
+	}
+
+	render(planned, table.Row{"Spent on", "Project", "Issue ID", "Activity", "Hours", "Comment", "Status"}, rows)
+}
@@ -2,8 +2,13 @@ package cmd
 
 import (
 	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/mightymatth/arcli/config"
+	"github.com/mightymatth/arcli/parse"
 
 	"github.com/jedib0t/go-pretty/table"
 	"github.com/mightymatth/arcli/utils"
@@ -41,12 +46,22 @@ func newDefaultsAddCmd() *cobra.Command {
 		Args:    validDefaultsAddArgs(),
 		Short:   "Add default value",
 		Run: func(cmd *cobra.Command, args []string) {
-			err := config.SetDefault(config.DefaultsKey(args[0]), args[1])
+			value := args[1]
+			if args[0] == string(config.Holidays) {
+				var err error
+				value, err = holidaysPath(value)
+				if err != nil {
+					fmt.Println("Cannot fetch holidays file absolute path:", err)
+					return
+				}
+			}
+
+			err := config.SetDefault(config.DefaultsKey(args[0]), value)
 			if err != nil {
 				fmt.Println(err)
 				return
 			}
-			fmt.Printf("'%v: %v' has been successfully added to defaults.\n", args[0], args[1])
+			fmt.Printf("'%v: %v' has been successfully added to defaults.\n", args[0], value)
 		},
 	}
 
@@ -66,7 +81,7 @@ func validDefaultsAddArgs() cobra.PositionalArgs {
 		}
 
 		if args[0] == string(config.Activity) {
-			activities, err := RClient.GetActivities()
+			activities, err := RClient.GetActivities(ctx)
 			if err != nil {
 				return fmt.Errorf("cannot get time entry activities: %v", err)
 			}
@@ -78,24 +93,85 @@ func validDefaultsAddArgs() cobra.PositionalArgs {
 			}
 		}
 
+		if args[0] == string(config.Rounding) {
+			rounding, err := parse.Duration(args[1])
+			if err != nil || rounding <= 0 {
+				return fmt.Errorf("rounding must be positive duration (e.g. '15m', '0:15' or '0.25')")
+			}
+		}
+
+		if args[0] == string(config.SessionGap) || args[0] == string(config.SessionStart) {
+			_, err = parse.Duration(args[1])
+			if err != nil {
+				return err
+			}
+		}
+
+		if args[0] == string(config.DateOrder) {
+			_, err = parse.ParseDateOrder(args[1])
+			if err != nil {
+				return err
+			}
+		}
+
+		if args[0] == string(config.HookMode) && !contains(hookModes, args[1]) {
+			return fmt.Errorf("invalid hook mode (allowed ones: [%v])", utils.PrintWithDelimiter(hookModes))
+		}
+
+		if args[0] == string(config.BranchPattern) {
+			_, err = regexp.Compile(args[1])
+			if err != nil {
+				return fmt.Errorf("invalid branch pattern: %v", err)
+			}
+		}
+
+		if args[0] == string(config.MaxAttempts) {
+			attempts, err := strconv.Atoi(args[1])
+			if err != nil || attempts <= 0 {
+				return fmt.Errorf("max attempts must be positive number (1 turns retrying off)")
+			}
+		}
+
+		if args[0] == string(config.Holidays) {
+			_, err = readHolidays(args[1])
+			if err != nil {
+				return fmt.Errorf("cannot read holidays file: %v", err)
+			}
+		}
+
+		if strings.HasSuffix(args[0], "target") {
+			_, err = parseTimesheetHours(args[1])
+			if err != nil {
+				return fmt.Errorf("target must be hours (e.g. '8', '7.5' or '7h30m')")
+			}
+		}
+
 		return nil
 	}
 }
 
 func drawDefaults(defaults map[string]string) {
-	if len(defaults) == 0 {
+	if len(defaults) == 0 && out.IsTable() {
 		fmt.Println("You have no previously defaults set.")
 		fmt.Printf("These can be set with: '%v'\n", newDefaultsAddCmd().UseLine())
 		return
 	}
 
-	t := utils.NewTable()
-	t.AppendHeader(table.Row{"Default entity", "Value"})
-	for key, val := range defaults {
-		t.AppendRow(table.Row{key, val})
+	rows := make([]table.Row, 0, len(defaults))
+	for _, key := range sortedKeys(defaults) {
+		rows = append(rows, table.Row{key, defaults[key]})
 	}
 
-	t.Render()
+	render(defaults, table.Row{"Default entity", "Value"}, rows)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
 }
 
 func contains(a []string, x string) bool {
@@ -106,3 +182,12 @@ func contains(a []string, x string) bool {
 	}
 	return false
 }
+
+func anyFlagChanged(cmd *cobra.Command, names ...string) bool {
+	for _, name := range names {
+		if cmd.Flags().Changed(name) {
+			return true
+		}
+	}
+	return false
+}
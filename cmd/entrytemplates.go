@@ -0,0 +1,322 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/jedib0t/go-pretty/table"
+	"github.com/mightymatth/arcli/client"
+	"github.com/mightymatth/arcli/config"
+	"github.com/mightymatth/arcli/parse"
+	"github.com/spf13/cobra"
+)
+
+var entryTemplate config.EntryTemplate
+
+func newEntryTemplatesCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:     "template",
+		Aliases: []string{"templates", "tpl"},
+		Short:   "Named time entry templates (used with 'arcli log use [name]')",
+		Long: `Named time entry templates, used with 'arcli log use [name]'. Values set in template
+override defaults, and values given when template is used override the template.`,
+		Example: `arcli log template add standup --project internal --activity Meeting -t 0.25 -m "Daily standup"
+arcli log use standup -d yesterday`,
+	}
+
+	c.AddCommand(newEntryTemplatesListCmd())
+	c.AddCommand(newEntryTemplatesAddCmd())
+	c.AddCommand(newEntryTemplatesEditCmd())
+	c.AddCommand(newEntryTemplatesDeleteCmd())
+
+	return c
+}
+
+func newEntryTemplatesListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls", "all"},
+		Short:   "List of all time entry templates",
+		Run: func(cmd *cobra.Command, args []string) {
+			drawEntryTemplates(config.GetEntryTemplates())
+		},
+	}
+}
+
+func newEntryTemplatesAddCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:     "add [templateName]",
+		Aliases: []string{"set", "new"},
+		Args:    validEntryTemplateNameArgs(),
+		Short:   "Add time entry template",
+		Run: func(cmd *cobra.Command, args []string) {
+			err := validateEntryTemplate(entryTemplate)
+			if err != nil {
+				fmt.Println("Invalid template:", err)
+				return
+			}
+
+			err = config.SetEntryTemplate(args[0], entryTemplate)
+			if err != nil {
+				fmt.Println(err)
+				return
+			}
+			fmt.Printf("Template '%v' has been successfully saved.\n", args[0])
+		},
+	}
+
+	addEntryTemplateFlags(c)
+
+	return c
+}
+
+func newEntryTemplatesEditCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:     "edit [templateName]",
+		Aliases: []string{"update", "u"},
+		Args:    cobra.ExactArgs(1),
+		Short:   "Change values of time entry template (empty value removes it)",
+		Example: `arcli log template edit standup -t 0.5 --activity ""`,
+		Run: func(cmd *cobra.Command, args []string) {
+			template, found := config.GetEntryTemplate(args[0])
+			if !found {
+				fmt.Printf("Template with name '%v' does not exist.\n", args[0])
+				return
+			}
+
+			if cmd.Flags().Changed("issue") {
+				template.Issue = entryTemplate.Issue
+				if !cmd.Flags().Changed("project") {
+					template.Project = ""
+				}
+			}
+			if cmd.Flags().Changed("project") {
+				template.Project = entryTemplate.Project
+				if !cmd.Flags().Changed("issue") {
+					template.Issue = ""
+				}
+			}
+			if cmd.Flags().Changed("activity") {
+				template.Activity = entryTemplate.Activity
+			}
+			if cmd.Flags().Changed("hours") {
+				template.Hours = entryTemplate.Hours
+			}
+			if cmd.Flags().Changed("message") {
+				template.Comment = entryTemplate.Comment
+			}
+
+			err := validateEntryTemplate(template)
+			if err != nil {
+				fmt.Println("Invalid template:", err)
+				return
+			}
+
+			err = config.SetEntryTemplate(args[0], template)
+			if err != nil {
+				fmt.Println(err)
+				return
+			}
+			fmt.Printf("Template '%v' has been successfully updated.\n", args[0])
+		},
+	}
+
+	addEntryTemplateFlags(c)
+
+	return c
+}
+
+func newEntryTemplatesDeleteCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "delete [templateName]",
+		Aliases: []string{"remove", "rm", "del"},
+		Args:    cobra.ExactArgs(1),
+		Short:   "Remove time entry template",
+		Run: func(cmd *cobra.Command, args []string) {
+			_, found := config.GetEntryTemplate(args[0])
+			if !found {
+				fmt.Printf("Template with name '%v' does not exist, so can't be deleted.\n", args[0])
+				return
+			}
+
+			err := config.RemoveEntryTemplate(args[0])
+			if err != nil {
+				fmt.Println("Cannot delete template:", err)
+				return
+			}
+
+			fmt.Printf("Template with name '%v' has been deleted.\n", args[0])
+		},
+	}
+}
+
+func addEntryTemplateFlags(c *cobra.Command) {
+	c.Flags().StringVarP(&entryTemplate.Issue, "issue", "i", "",
+		"Issue ID or alias")
+	c.Flags().StringVarP(&entryTemplate.Project, "project", "p", "",
+		"Project ID, identifier or alias")
+	c.Flags().StringVarP(&entryTemplate.Activity, "activity", "a", "",
+		"The name of activity for spent time (this overrides default config value)")
+	c.Flags().StringVarP(&entryTemplate.Hours, "hours", "t", "",
+		"The number of spent hours ('0.25', '0:15', '15m')")
+	c.Flags().StringVarP(&entryTemplate.Comment, "message", "m", "",
+		"Short comment")
+}
+
+func validEntryTemplateNameArgs() cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		err := cobra.ExactArgs(1)(cmd, args)
+		if err != nil {
+			return err
+		}
+
+		namePattern := "^[[:alnum:]-_]{1,30}$"
+		if !regexp.MustCompile(namePattern).MatchString(args[0]) {
+			return fmt.Errorf("template name must have pattern '%v'", namePattern)
+		}
+
+		return nil
+	}
+}
+
+func validateEntryTemplate(template config.EntryTemplate) error {
+	if template.Issue != "" && template.Project != "" {
+		return fmt.Errorf("set either issue or project, not both")
+	}
+
+	if template.Issue != "" {
+		if _, err := resolveIssueID(template.Issue); err != nil {
+			return err
+		}
+	}
+
+	if template.Project != "" {
+		if _, err := resolveProjectID(template.Project); err != nil {
+			return fmt.Errorf("cannot find project '%v': %v", template.Project, err)
+		}
+	}
+
+	if template.Activity != "" {
+		if _, err := resolveActivityID(template.Activity); err != nil {
+			return err
+		}
+	}
+
+	if template.Hours != "" {
+		if _, err := parse.Hours(template.Hours); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func newTimeEntriesUseCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:     "use [templateName]",
+		Args:    cobra.ExactArgs(1),
+		Short:   "Add time entry from template",
+		Example: `arcli log use standup -d yesterday`,
+		Run:     timeEntriesUseFunc,
+	}
+
+	c.Flags().StringVarP(&spentOn, "date", "d", "today",
+		"The date the time was spent ('today', 'monday', 'last friday', '-3d', '2w ago', '10/15', "+
+			"'2020-01-15'), or range of dates to add time entry on every day ('mon..wed')")
+	c.Flags().StringVarP(&hours, "hours", "t", "",
+		"The number of spent hours ('1.5', '1:30', '1h30m', '90m'; this overrides template value)")
+	c.Flags().StringVarP(&activity, "activity", "a", "",
+		"The name of activity for spent time (this overrides template and default config value)")
+	c.Flags().StringVarP(&comments, "message", "m", "",
+		"Short comment (this overrides template value)")
+
+	return c
+}
+
+func timeEntriesUseFunc(cmd *cobra.Command, args []string) {
+	template, found := config.GetEntryTemplate(args[0])
+	if !found {
+		fmt.Printf("Template with name '%v' does not exist.\n", args[0])
+		fmt.Printf("Templates can be added with: '%v'\n", newEntryTemplatesAddCmd().UseLine())
+		return
+	}
+
+	var entryPost client.TimeEntryPost
+	switch {
+	case template.Issue != "":
+		issueID, err := resolveIssueID(template.Issue)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		entryPost.IssueID = int(issueID)
+	case template.Project != "":
+		projectID, err := resolveProjectID(template.Project)
+		if err != nil {
+			fmt.Printf("Cannot find project '%v': %v\n", template.Project, err)
+			return
+		}
+		entryPost.ProjectID = int(projectID)
+	default:
+		fmt.Printf("Template '%v' has neither issue nor project set.\n", args[0])
+		return
+	}
+
+	if cmd.Flags().Changed("activity") {
+		template.Activity = activity
+	}
+	activityID, err := resolveActivityID(template.Activity)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	entryPost.ActivityID = int(activityID)
+
+	if cmd.Flags().Changed("hours") {
+		template.Hours = hours
+	}
+	if template.Hours == "" {
+		fmt.Println("Provide hours either by flag or template")
+		return
+	}
+	spentHours, err := parse.Hours(template.Hours)
+	if err != nil {
+		fmt.Printf("Cannot parse hours value: %v\n", err)
+		return
+	}
+	entryPost.Hours = float32(spentHours)
+	if entryPost.Hours <= 0 {
+		fmt.Println("Provide hours either by flag or template")
+		return
+	}
+
+	entryPost.Comments = template.Comment
+	if cmd.Flags().Changed("message") {
+		entryPost.Comments = comments
+	}
+
+	days, err := dateParser().Range(spentOn)
+	if err != nil {
+		fmt.Printf("Cannot parse date value: %v\n", err)
+		return
+	}
+
+	addTimeEntries(entryPost, days)
+}
+
+func drawEntryTemplates(templates map[string]config.EntryTemplate) {
+	if len(templates) == 0 && out.IsTable() {
+		fmt.Println("You have no time entry templates.")
+		fmt.Printf("These can be added with: '%v'\n", newEntryTemplatesAddCmd().UseLine())
+		return
+	}
+
+	rows := make([]table.Row, 0, len(templates))
+	for _, name := range sortedKeys(templates) {
+		template := templates[name]
+		rows = append(rows, table.Row{name, template.Issue, template.Project, template.Activity, template.Hours,
+			template.Comment})
+	}
+
+	render(templates, table.Row{"Name", "Issue", "Project", "Activity", "Hours", "Comment"}, rows)
+}
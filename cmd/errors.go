@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/mightymatth/arcli/client"
+)
+
+// describeError returns user-friendly description of error returned by client.
+// Resource (e.g. 'issue 123') is used to describe the error if it cannot be found.
+func describeError(err error, resource string) string {
+	var netErr net.Error
+	switch {
+	case errors.Is(err, context.Canceled):
+		return "interrupted"
+	case errors.Is(err, context.DeadlineExceeded) || errors.As(err, &netErr) && netErr.Timeout():
+		return fmt.Sprintf("Redmine server did not respond in time (timeout is %v, see --timeout)", timeoutFlag)
+	case errors.Is(err, client.ErrMissingCredentials):
+		return "you are not logged in, run 'arcli login'"
+	case client.IsUnauthorized(err):
+		return "your API key is invalid or was revoked, run 'arcli login'"
+	case client.IsForbidden(err):
+		if resource != "" {
+			return "you are not allowed to access " + resource
+		}
+		return "you are not allowed to do that"
+	case client.IsNotFound(err) && resource != "":
+		return resource + " not found"
+	default:
+		return err.Error()
+	}
+}
@@ -0,0 +1,367 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mightymatth/arcli/client"
+	"github.com/mightymatth/arcli/config"
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportFrom    string
+	exportTo      string
+	exportUser    string
+	exportProject string
+	exportIssue   string
+	exportFormat  string
+	exportColumns []string
+	exportFile    string
+)
+
+const (
+	exportCSV  = "csv"
+	exportJSON = "json"
+	// exportXLSX is CSV that spreadsheet applications open with correct encoding.
+	exportXLSX = "xlsx"
+)
+
+var exportFormats = []string{exportCSV, exportJSON, exportXLSX}
+
+// exportColumn is a column of exported time entries.
+type exportColumn struct {
+	name  string
+	value func(entry client.TimeEntry, subjects map[int64]string) interface{}
+}
+
+var exportAllColumns = []exportColumn{
+	{"id", func(e client.TimeEntry, _ map[int64]string) interface{} { return e.ID }},
+	{"date", func(e client.TimeEntry, _ map[int64]string) interface{} {
+		return e.SpentOn.Format(client.DateTimeFormat)
+	}},
+	{"user_id", func(e client.TimeEntry, _ map[int64]string) interface{} { return e.User.ID }},
+	{"user", func(e client.TimeEntry, _ map[int64]string) interface{} { return e.User.Name }},
+	{"project_id", func(e client.TimeEntry, _ map[int64]string) interface{} { return e.Project.ID }},
+	{"project", func(e client.TimeEntry, _ map[int64]string) interface{} { return e.Project.Name }},
+	{"issue", func(e client.TimeEntry, _ map[int64]string) interface{} {
+		if e.Issue.ID == 0 {
+			return nil
+		}
+		return e.Issue.ID
+	}},
+	{"subject", func(e client.TimeEntry, subjects map[int64]string) interface{} { return subjects[e.Issue.ID] }},
+	{"activity", func(e client.TimeEntry, _ map[int64]string) interface{} { return e.Activity.Name }},
+	{"hours", func(e client.TimeEntry, _ map[int64]string) interface{} { return e.Hours }},
+	{"comment", func(e client.TimeEntry, _ map[int64]string) interface{} { return e.Comments }},
+	{"created", func(e client.TimeEntry, _ map[int64]string) interface{} { return e.CreatedOn.Format(time.RFC3339) }},
+	{"updated", func(e client.TimeEntry, _ map[int64]string) interface{} { return e.UpdatedOn.Format(time.RFC3339) }},
+}
+
+var exportDefaultColumns = []string{"date", "user", "project", "issue", "subject", "activity", "hours", "comment"}
+
+func newTimeEntriesExportCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "export",
+		Args:  cobra.NoArgs,
+		Short: "Export time entries of date range",
+		Long: fmt.Sprintf(`Export time entries of date range as CSV, JSON or CSV that can be opened in
+spreadsheet applications (xlsx). Entries are sorted by date.
+
+Available columns: %v`, strings.Join(exportColumnNames(), ", ")),
+		Example: `arcli log export --from 2026-09-01 --to 2026-09-30 -f september.csv
+arcli log export --from 2026-09-01 --project webshop --user all --columns date,user,hours`,
+		Run: timeEntriesExportFunc,
+	}
+
+	c.Flags().StringVar(&exportFrom, "from", "",
+		"The first day of range ('today', 'yesterday', '2020-01-15'; default first day of this month)")
+	c.Flags().StringVar(&exportTo, "to", "today",
+		"The last day of range ('today', 'yesterday', '2020-01-15')")
+	c.Flags().StringVarP(&exportUser, "user", "u", "me",
+		"User whose time entries are exported ('me', 'all', id or login)")
+	c.Flags().StringVarP(&exportProject, "project", "p", "",
+		"Export only time entries of project (id, identifier or alias)")
+	c.Flags().StringVarP(&exportIssue, "issue", "i", "",
+		"Export only time entries of issue (id or alias)")
+	c.Flags().StringVar(&exportFormat, "format", exportCSV,
+		fmt.Sprintf("Export format (%v)", strings.Join(exportFormats, ", ")))
+	c.Flags().StringSliceVarP(&exportColumns, "columns", "c", exportDefaultColumns,
+		"Exported columns, in order")
+	c.Flags().StringVarP(&exportFile, "file", "f", "",
+		"Write export to file instead of standard output")
+
+	return c
+}
+
+func timeEntriesExportFunc(_ *cobra.Command, _ []string) {
+	if !contains(exportFormats, exportFormat) {
+		fmt.Printf("Invalid export format '%v' (allowed ones: [%v])\n",
+			exportFormat, strings.Join(exportFormats, ", "))
+		return
+	}
+
+	columns, err := selectExportColumns(exportColumns)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	query, err := timeEntriesRangeQuery(exportFrom, exportTo, exportUser, exportProject, exportIssue)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	entries, err := RClient.GetTimeEntries(ctx, query.Encode())
+	if err != nil {
+		fmt.Println("Cannot get time entries:", err)
+		return
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if !entries[i].SpentOn.Equal(entries[j].SpentOn.Time) {
+			return entries[i].SpentOn.Before(entries[j].SpentOn.Time)
+		}
+		return entries[i].ID < entries[j].ID
+	})
+
+	var subjects map[int64]string
+	for _, column := range columns {
+		if column.name == "subject" {
+			ids := make([]int64, 0, len(entries))
+			for _, entry := range entries {
+				if entry.Issue.ID != 0 {
+					ids = append(ids, entry.Issue.ID)
+				}
+			}
+			subjects = issueSubjects(ids)
+		}
+	}
+
+	var w io.Writer = os.Stdout
+	if exportFile != "" {
+		f, err := os.Create(exportFile)
+		if err != nil {
+			fmt.Println("Cannot create export file:", err)
+			return
+		}
+		defer f.Close()
+		w = f
+	}
+
+	switch exportFormat {
+	case exportJSON:
+		err = writeExportJSON(w, entries, columns, subjects)
+	default:
+		err = writeExportCSV(w, entries, columns, subjects, exportFormat == exportXLSX)
+	}
+	if err != nil {
+		fmt.Println("Cannot export time entries:", err)
+		return
+	}
+
+	if exportFile != "" {
+		var total float64
+		for _, entry := range entries {
+			total += entry.Hours
+		}
+		fmt.Printf("Exported %v time entries (%v hours) to %v\n", len(entries), formatTimesheetHours(total), exportFile)
+	}
+}
+
+func exportColumnNames() []string {
+	names := make([]string, 0, len(exportAllColumns))
+	for _, column := range exportAllColumns {
+		names = append(names, column.name)
+	}
+	return names
+}
+
+func selectExportColumns(names []string) ([]exportColumn, error) {
+	columns := make([]exportColumn, 0, len(names))
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+
+		found := false
+		for _, column := range exportAllColumns {
+			if column.name == name {
+				columns = append(columns, column)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("invalid column '%v' (allowed ones: [%v])",
+				name, strings.Join(exportColumnNames(), ", "))
+		}
+	}
+
+	return columns, nil
+}
+
+// timeEntriesRangeQuery returns query for time entries of user, project and issue
+// spent between from and to dates (both included). Empty from is the first day
+// of this month, and empty user means all users.
+func timeEntriesRangeQuery(from, to, user, project, issue string) (url.Values, error) {
+	query := url.Values{}
+
+	if from == "" {
+		from = time.Date(timeNow.Year(), timeNow.Month(), 1, 0, 0, 0, 0, time.UTC).Format(client.DateTimeFormat)
+	}
+	from, err := spentOnModify(from)
+	if err != nil {
+		return nil, fmt.Errorf("invalid from date: %v", err)
+	}
+	if to == "" {
+		to = "today"
+	}
+	to, err = spentOnModify(to)
+	if err != nil {
+		return nil, fmt.Errorf("invalid to date: %v", err)
+	}
+	if to < from {
+		return nil, fmt.Errorf("from date %v is after to date %v", from, to)
+	}
+	query.Set("from", from)
+	query.Set("to", to)
+
+	switch user {
+	case "all", "":
+	case "me":
+		query.Set("user_id", "me")
+	default:
+		userID, err := resolveUserID(user)
+		if err != nil {
+			return nil, fmt.Errorf("invalid user: %v", err)
+		}
+		query.Set("user_id", strconv.FormatInt(userID, 10))
+	}
+
+	if project != "" {
+		projectID, err := resolveProjectID(project)
+		if err != nil {
+			return nil, fmt.Errorf("invalid project: %v", err)
+		}
+		query.Set("project_id", strconv.FormatInt(projectID, 10))
+	}
+
+	if issue != "" {
+		issueID := issue
+		if alias, found := config.GetAlias(issue); found {
+			issueID = alias
+		}
+		if _, err = strconv.ParseInt(issueID, 10, 64); err != nil {
+			return nil, fmt.Errorf("issue id must be integer, but given %v", issue)
+		}
+		query.Set("issue_id", issueID)
+	}
+
+	return query, nil
+}
+
+func writeExportCSV(w io.Writer, entries []client.TimeEntry, columns []exportColumn,
+	subjects map[int64]string, spreadsheet bool) error {
+	if spreadsheet {
+		// byte order mark makes spreadsheet applications read the file as UTF-8
+		_, err := io.WriteString(w, "\ufeff")
+		if err != nil {
+			return err
+		}
+	}
+
+	cw := csv.NewWriter(w)
+	cw.UseCRLF = true
+
+	header := make([]string, 0, len(columns))
+	for _, column := range columns {
+		header = append(header, column.name)
+	}
+	err := cw.Write(header)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		record := make([]string, 0, len(columns))
+		for _, column := range columns {
+			value := column.value(entry, subjects)
+			switch v := value.(type) {
+			case nil:
+				record = append(record, "")
+			case float64:
+				record = append(record, strconv.FormatFloat(v, 'f', -1, 64))
+			default:
+				record = append(record, fmt.Sprint(v))
+			}
+		}
+
+		err = cw.Write(record)
+		if err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func writeExportJSON(w io.Writer, entries []client.TimeEntry, columns []exportColumn,
+	subjects map[int64]string) error {
+	records := make([]exportRecord, 0, len(entries))
+	for _, entry := range entries {
+		record := make(exportRecord, 0, len(columns))
+		for _, column := range columns {
+			record = append(record, exportField{column.name, column.value(entry, subjects)})
+		}
+		records = append(records, record)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(records)
+}
+
+type exportField struct {
+	name  string
+	value interface{}
+}
+
+// exportRecord is exported time entry, encoded as JSON object with fields in
+// the order of selected columns.
+type exportRecord []exportField
+
+func (r exportRecord) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, field := range r {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		name, err := json.Marshal(field.name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(field.value)
+		if err != nil {
+			return nil, err
+		}
+
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mightymatth/arcli/client"
+)
+
+func TestWriteExportJSON(t *testing.T) {
+	entries := []client.TimeEntry{{ID: 7, Hours: 1.5, Comments: "review"}, {ID: 8, Hours: 0.25}}
+	entries[0].Issue.ID = 2012300
+
+	tests := []struct {
+		columns []string
+		want    string
+	}{
+		{[]string{"id", "hours"}, `[{"id":7,"hours":1.5},{"id":8,"hours":0.25}]`},
+		{[]string{"hours", "id"}, `[{"hours":1.5,"id":7},{"hours":0.25,"id":8}]`},
+		{[]string{"issue", "comment", "subject"},
+			`[{"issue":2012300,"comment":"review","subject":"Fix login"},` +
+				`{"issue":null,"comment":"","subject":""}]`},
+	}
+
+	for _, tt := range tests {
+		columns, err := selectExportColumns(tt.columns)
+		if err != nil {
+			t.Fatalf("selectExportColumns(%v) error = %v", tt.columns, err)
+		}
+
+		var b strings.Builder
+		err = writeExportJSON(&b, entries, columns, map[int64]string{2012300: "Fix login"})
+		if err != nil {
+			t.Fatalf("writeExportJSON() error = %v", err)
+		}
+
+		got := strings.Join(strings.Fields(b.String()), "")
+		if got != strings.Join(strings.Fields(tt.want), "") {
+			t.Errorf("writeExportJSON(%v) = %v, want %v", tt.columns, b.String(), tt.want)
+		}
+	}
+}
+
+func TestWriteExportJSONEmpty(t *testing.T) {
+	var b strings.Builder
+	err := writeExportJSON(&b, nil, exportAllColumns, nil)
+	if err != nil {
+		t.Fatalf("writeExportJSON() error = %v", err)
+	}
+
+	if got := strings.TrimSpace(b.String()); got != "[]" {
+		t.Errorf("writeExportJSON() = %v, want []", got)
+	}
+}
@@ -0,0 +1,430 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jedib0t/go-pretty/table"
+	"github.com/mightymatth/arcli/client"
+	"github.com/mightymatth/arcli/config"
+	"github.com/mightymatth/arcli/git"
+	"github.com/mightymatth/arcli/parse"
+	"github.com/mightymatth/arcli/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	fromGitRepo         string
+	fromGitSince        string
+	fromGitTo           string
+	fromGitAuthor       string
+	fromGitSessionGap   string
+	fromGitSessionStart string
+	fromGitYes          bool
+)
+
+const (
+	// defaultSessionGap is the longest pause between commits in the same work session.
+	defaultSessionGap = 2 * time.Hour
+	// defaultSessionStart is time spent before the first commit of work session.
+	defaultSessionStart = 30 * time.Minute
+	// maxCommentLength is the number of characters time entry comment is cut to.
+	maxCommentLength = 255
+)
+
+// gitProposal represents time entry proposed from commits referencing the same
+// issue on the same day.
+type gitProposal struct {
+	Date     client.DateTime `json:"date"`
+	IssueID  int64           `json:"issue_id"`
+	Subject  string          `json:"subject"`
+	Hours    float64         `json:"hours"`
+	Comments string          `json:"comments"`
+	Commits  []string        `json:"commits"`
+
+	duration time.Duration
+	subjects []string
+}
+
+func newTimeEntriesFromGitCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:     "from-git",
+		Aliases: []string{"git"},
+		Args:    cobra.NoArgs,
+		Short:   "Propose time entries from git commits referencing issues",
+		Long: `Propose time entries from git commits of all local branches. Commits reference issues in
+message (e.g. 'refs #20123', 'fixes #20123') or by the name of their branch (e.g. 'feature/20123-foo').
+
+Time spent on commit is the time since the previous commit on the same day, if it is shorter
+than session gap. Otherwise, the commit starts a new work session and session start time is
+spent on it. Time of commits referencing the same issue on the same day is summed up and
+rounded to the rounding set in defaults.
+
+Every proposed time entry can be accepted, edited or dropped before it is created.`,
+		Example: `arcli log from-git --since monday
+arcli log from-git --repo ~/src/webshop --since "last friday" --session-gap 1h`,
+		Run: timeEntriesFromGitFunc,
+	}
+
+	c.Flags().StringVar(&fromGitRepo, "repo", ".",
+		"Path to git repository")
+	c.Flags().StringVar(&fromGitSince, "since", "yesterday",
+		"The first day of commits ('today', 'monday', 'last friday', '-3d', '2020-01-15')")
+	c.Flags().StringVar(&fromGitTo, "to", "today",
+		"The last day of commits ('today', 'monday', 'last friday', '-3d', '2020-01-15')")
+	c.Flags().StringVar(&fromGitAuthor, "author", "me",
+		"Author of commits ('me' for configured git user, 'all' for everyone, or name or email)")
+	c.Flags().StringVar(&fromGitSessionGap, "session-gap", "",
+		fmt.Sprintf("The longest pause between commits in the same session (default %v or sessiongap default)",
+			defaultSessionGap))
+	c.Flags().StringVar(&fromGitSessionStart, "session-start", "",
+		fmt.Sprintf("Time spent before the first commit of session (default %v or sessionstart default)",
+			defaultSessionStart))
+	c.Flags().StringVarP(&activity, "activity", "a", "",
+		"The name of activity for spent time (this overrides default config value)")
+	c.Flags().BoolVarP(&fromGitYes, "yes", "y", false,
+		"Create all proposed time entries without asking")
+
+	return c
+}
+
+func timeEntriesFromGitFunc(_ *cobra.Command, _ []string) {
+	gap, err := sessionDuration(fromGitSessionGap, config.SessionGap, defaultSessionGap)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	start, err := sessionDuration(fromGitSessionStart, config.SessionStart, defaultSessionStart)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	rounding, err := timerRounding()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	activityID, err := resolveActivityID(activity)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	since, err := dateParser().Date(fromGitSince)
+	if err != nil {
+		fmt.Printf("Cannot parse since date: %v\n", err)
+		return
+	}
+	to, err := dateParser().Date(fromGitTo)
+	if err != nil {
+		fmt.Printf("Cannot parse to date: %v\n", err)
+		return
+	}
+
+	options := git.LogOptions{
+		Since:  time.Date(since.Year(), since.Month(), since.Day(), 0, 0, 0, 0, time.Local),
+		Until:  time.Date(to.Year(), to.Month(), to.Day()+1, 0, 0, 0, 0, time.Local),
+		Author: fromGitAuthor,
+	}
+	switch fromGitAuthor {
+	case "all", "":
+		options.Author = ""
+	case "me":
+		options.Author, err = git.UserEmail(fromGitRepo)
+		if err != nil {
+			fmt.Println("Cannot get git user email (set it in git config or use --author flag):", err)
+			return
+		}
+	}
+
+	commits, err := git.Log(fromGitRepo, options)
+	if err != nil {
+		fmt.Println("Cannot read commits:", err)
+		return
+	}
+
+	branchIssues, err := branchCommitIssues(fromGitRepo, options)
+	if err != nil {
+		fmt.Println("Cannot read branches:", err)
+		return
+	}
+
+	proposals, unreferenced := proposeGitEntries(commits, branchIssues, gap, start, rounding)
+	if unreferenced != 0 && out.IsTable() {
+		fmt.Printf("%v commits do not reference any issue.\n", unreferenced)
+	}
+	if len(proposals) == 0 {
+		if out.IsTable() {
+			fmt.Println("There are no commits referencing issues.")
+		} else {
+			drawGitProposals([]*gitProposal{})
+		}
+		return
+	}
+
+	ids := make([]int64, 0, len(proposals))
+	for _, p := range proposals {
+		ids = append(ids, p.IssueID)
+	}
+	subjects := issueSubjects(ids)
+	for _, p := range proposals {
+		p.Subject = subjects[p.IssueID]
+	}
+
+	drawGitProposals(proposals)
+
+	if !fromGitYes {
+		proposals = reviewGitProposals(proposals)
+	}
+
+	created := 0
+	for _, p := range proposals {
+		entry, err := RClient.AddTimeEntry(ctx, client.TimeEntryPost{
+			IssueID:    int(p.IssueID),
+			SpentOn:    p.Date,
+			Hours:      float32(p.Hours),
+			ActivityID: int(activityID),
+			Comments:   p.Comments,
+		})
+		if err != nil {
+			fmt.Printf("Cannot create time entry for issue #%v on %v: %v\n", p.IssueID,
+				p.Date.Format(client.DayDateFormat), err)
+			continue
+		}
+		created++
+		if out.IsTable() {
+			fmt.Printf("Time entry %v created for issue #%v on %v.\n", entry.ID, p.IssueID,
+				p.Date.Format(client.DayDateFormat))
+		}
+	}
+
+	if out.IsTable() {
+		fmt.Printf("%v of %v time entries created.\n", created, len(proposals))
+	}
+}
+
+// sessionDuration returns duration given by flag, or set in defaults, or the
+// fallback one.
+func sessionDuration(flag string, key config.DefaultsKey, fallback time.Duration) (time.Duration, error) {
+	value := flag
+	if value == "" {
+		value = config.Defaults()[string(key)]
+	}
+	if value == "" {
+		return fallback, nil
+	}
+
+	duration, err := parse.Duration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %v: %v", key, err)
+	}
+
+	return duration, nil
+}
+
+// branchCommitIssues returns issues referenced by names of branches (e.g.
+// 'feature/20123-foo') for commits of these branches. Commits that also belong
+// to branches without issue reference (e.g. 'main') are skipped.
+func branchCommitIssues(dir string, options git.LogOptions) (map[string]int64, error) {
+	branches, err := git.Branches(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	pattern, err := branchPattern()
+	if err != nil {
+		return nil, err
+	}
+
+	issueBranches := make(map[string]int64)
+	var others []string
+	for _, branch := range branches {
+		if id, found := branchIssueID(branch, pattern); found {
+			issueBranches[branch] = id
+			continue
+		}
+		others = append(others, branch)
+	}
+
+	issues := make(map[string]int64)
+	for _, branch := range sortedKeys(issueBranches) {
+		options.Revisions = append([]string{branch, "--not"}, others...)
+		commits, err := git.Log(dir, options)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, commit := range commits {
+			if _, found := issues[commit.Hash]; !found {
+				issues[commit.Hash] = issueBranches[branch]
+			}
+		}
+	}
+
+	return issues, nil
+}
+
+// proposeGitEntries groups commits by day and referenced issue, and estimates
+// time spent on them. Issues referenced in commit message have precedence over
+// the ones in branch name. It returns the number of commits without issue reference.
+func proposeGitEntries(commits []git.Commit, branchIssues map[string]int64,
+	gap, start, rounding time.Duration) ([]*gitProposal, int) {
+	proposals := make(map[string]*gitProposal)
+	unreferenced := 0
+	var previous time.Time
+	for _, commit := range commits {
+		commitTime := commit.Time.In(time.Local)
+		day := time.Date(commitTime.Year(), commitTime.Month(), commitTime.Day(), 0, 0, 0, 0, time.UTC)
+
+		spent := start
+		if previous.YearDay() == commitTime.YearDay() && previous.Year() == commitTime.Year() &&
+			commitTime.Sub(previous) <= gap {
+			spent = commitTime.Sub(previous)
+		}
+		previous = commitTime
+
+		issues := git.MessageIssues(commit.Subject + "\n" + commit.Body)
+		if len(issues) == 0 {
+			if id, found := branchIssues[commit.Hash]; found {
+				issues = []int64{id}
+			}
+		}
+		if len(issues) == 0 {
+			unreferenced++
+			continue
+		}
+
+		// time of commit referencing more issues is split between them
+		spent /= time.Duration(len(issues))
+		for _, id := range issues {
+			key := fmt.Sprintf("%v|%v", day.Format(client.DateTimeFormat), id)
+			p, found := proposals[key]
+			if !found {
+				p = &gitProposal{Date: *client.NewDateTime(day), IssueID: id}
+				proposals[key] = p
+			}
+
+			p.duration += spent
+			p.Commits = append(p.Commits, commit.Hash[:min(len(commit.Hash), 8)])
+			if !contains(p.subjects, commit.Subject) {
+				p.subjects = append(p.subjects, commit.Subject)
+			}
+		}
+	}
+
+	result := make([]*gitProposal, 0, len(proposals))
+	for _, p := range proposals {
+		p.Hours = roundDuration(p.duration, rounding).Hours()
+		p.Comments = truncateComment(strings.Join(p.subjects, "; "))
+		result = append(result, p)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].Date.Equal(result[j].Date.Time) {
+			return result[i].Date.Before(result[j].Date.Time)
+		}
+		return result[i].IssueID < result[j].IssueID
+	})
+
+	return result, unreferenced
+}
+
+func truncateComment(comment string) string {
+	runes := []rune(comment)
+	if len(runes) <= maxCommentLength {
+		return comment
+	}
+
+	return string(runes[:maxCommentLength-3]) + "..."
+}
+
+// reviewGitProposals asks user to accept, edit or drop every proposal and
+// returns the accepted ones.
+func reviewGitProposals(proposals []*gitProposal) []*gitProposal {
+	accepted := make([]*gitProposal, 0, len(proposals))
+	for i, p := range proposals {
+		for done := false; !done; {
+			answer, err := utils.Ask(fmt.Sprintf("[%v/%v] #%v on %v, %vh: %v\n[a]ccept, [e]dit, [d]rop, [q]uit? ",
+				i+1, len(proposals), p.IssueID, p.Date.Format(client.DayDateFormat), formatTimesheetHours(p.Hours),
+				p.Comments))
+			// closed input quits the review
+			if err != nil {
+				return accepted
+			}
+
+			switch strings.ToLower(answer) {
+			case "a", "accept", "y", "yes":
+				accepted = append(accepted, p)
+				done = true
+			case "e", "edit":
+				if editGitProposal(p) != nil {
+					return accepted
+				}
+			case "d", "drop", "n", "no":
+				done = true
+			case "q", "quit":
+				return accepted
+			}
+		}
+	}
+
+	return accepted
+}
+
+// editGitProposal asks user for new values of proposal. Error is returned if
+// input cannot be read.
+func editGitProposal(p *gitProposal) error {
+	answer, err := utils.Ask(fmt.Sprintf("Issue [%v]: ", p.IssueID))
+	if err != nil {
+		return err
+	}
+	if answer != "" {
+		id, err := resolveIssueID(answer)
+		if err != nil {
+			fmt.Println(err)
+		} else {
+			p.IssueID = id
+		}
+	}
+
+	answer, err = utils.Ask(fmt.Sprintf("Hours [%v]: ", formatTimesheetHours(p.Hours)))
+	if err != nil {
+		return err
+	}
+	if answer != "" {
+		hours, err := parse.Hours(answer)
+		if err != nil {
+			fmt.Println(err)
+		} else {
+			p.Hours = hours
+		}
+	}
+
+	answer, err = utils.Ask(fmt.Sprintf("Comment [%v]: ", p.Comments))
+	if err != nil {
+		return err
+	}
+	if answer != "" {
+		p.Comments = truncateComment(answer)
+	}
+
+	return nil
+}
+
+func drawGitProposals(proposals []*gitProposal) {
+	rows := make([]table.Row, 0, len(proposals))
+	var hours float64
+	for _, p := range proposals {
+		rows = append(rows, table.Row{p.Date.Format(client.DayDateFormat), p.IssueID, p.Subject,
+			formatTimesheetHours(p.Hours), len(p.Commits), p.Comments})
+		hours += p.Hours
+	}
+
+	render(proposals, table.Row{"Date", "Issue ID", "Subject", "Hours", "Commits", "Comment"}, rows)
+
+	if out.IsTable() {
+		fmt.Printf("%v time entries with %v hours proposed.\n", len(proposals), formatTimesheetHours(hours))
+	}
+}
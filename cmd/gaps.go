@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jedib0t/go-pretty/table"
+	"github.com/mightymatth/arcli/client"
+	"github.com/spf13/cobra"
+)
+
+var (
+	gapsFrom  string
+	gapsTo    string
+	gapsQuiet bool
+)
+
+const (
+	// gapsExitFound is the exit code of gaps command when there are days with missing time.
+	gapsExitFound = 1
+	// gapsExitError is the exit code of gaps command when days cannot be checked.
+	gapsExitError = 2
+	// defaultDailyTarget is used by gaps command if no targets are set in defaults.
+	defaultDailyTarget = 8
+)
+
+// logGap represents working day with less time logged than expected.
+type logGap struct {
+	Date     client.DateTime `json:"date"`
+	Logged   float64         `json:"logged"`
+	Expected float64         `json:"expected"`
+	Missing  float64         `json:"missing"`
+}
+
+func newTimeEntriesGapsCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:     "gaps",
+		Aliases: []string{"missing"},
+		Short:   "List working days with missing time",
+		Long: fmt.Sprintf(`List working days with less time logged than the target set in defaults
+(dailytarget, weeklytarget or per-weekday targets; %vh on weekdays if none is set).
+Public holidays from file set in holidays default and leave days are skipped.
+
+Exits with code %v if there are days with missing time and %v if they cannot be checked,
+so it can be used in scripts and shell prompts.`, defaultDailyTarget, gapsExitFound, gapsExitError),
+		Example: `arcli log gaps --from 2026-09-01 --to 2026-09-30
+arcli log gaps -q || echo "Log your time!"`,
+		Args:          cobra.NoArgs,
+		RunE:          timeEntriesGapsFunc,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+
+	c.Flags().StringVar(&gapsFrom, "from", "",
+		"The first day to check ('today', 'yesterday', '2020-01-15'; default first day of this month)")
+	c.Flags().StringVar(&gapsTo, "to", "yesterday",
+		"The last day to check ('today', 'yesterday', '2020-01-15')")
+	c.Flags().BoolVarP(&gapsQuiet, "quiet", "q", false,
+		"Print nothing, only set exit code")
+
+	return c
+}
+
+func timeEntriesGapsFunc(_ *cobra.Command, _ []string) error {
+	gaps, err := findLogGaps()
+	if err != nil {
+		if !gapsQuiet {
+			fmt.Println(err)
+		}
+		return exitError{code: gapsExitError}
+	}
+
+	if !gapsQuiet {
+		drawLogGaps(gaps)
+	}
+
+	if len(gaps) != 0 {
+		return exitError{code: gapsExitFound}
+	}
+
+	return nil
+}
+
+func findLogGaps() ([]logGap, error) {
+	calendar, err := loadWorkCalendar()
+	if err != nil {
+		return nil, err
+	}
+	if !calendar.targets.set {
+		for day := time.Monday; day <= time.Friday; day++ {
+			calendar.targets.hours[day] = defaultDailyTarget
+		}
+	}
+
+	from := gapsFrom
+	if from == "" {
+		from = time.Date(timeNow.Year(), timeNow.Month(), 1, 0, 0, 0, 0, time.UTC).Format(client.DateTimeFormat)
+	}
+	fromDate, err := spentOnParse(from)
+	if err != nil {
+		return nil, fmt.Errorf("invalid from date: %v", err)
+	}
+	toDate, err := spentOnParse(gapsTo)
+	if err != nil {
+		return nil, fmt.Errorf("invalid to date: %v", err)
+	}
+	// e.g. yesterday on the first day of month, so there is nothing to check
+	if toDate.Before(*fromDate) {
+		return []logGap{}, nil
+	}
+
+	entries, err := RClient.GetTimeEntries(ctx, fmt.Sprintf("from=%v&to=%v&user_id=me",
+		fromDate.Format(client.DateTimeFormat), toDate.Format(client.DateTimeFormat)))
+	if err != nil {
+		return nil, fmt.Errorf("cannot get time entries: %v", err)
+	}
+
+	logged := make(map[string]float64)
+	for _, entry := range entries {
+		logged[entry.SpentOn.Format(client.DateTimeFormat)] += entry.Hours
+	}
+
+	gaps := []logGap{}
+	for day := *fromDate; !day.After(*toDate); day = day.AddDate(0, 0, 1) {
+		expected, _ := calendar.Expected(day)
+		hours := logged[day.Format(client.DateTimeFormat)]
+		if expected == 0 || hours >= expected-0.005 {
+			continue
+		}
+
+		gaps = append(gaps, logGap{
+			Date:     *client.NewDateTime(day),
+			Logged:   hours,
+			Expected: expected,
+			Missing:  expected - hours,
+		})
+	}
+
+	return gaps, nil
+}
+
+func drawLogGaps(gaps []logGap) {
+	if len(gaps) == 0 && out.IsTable() {
+		fmt.Println("There are no working days with missing time.")
+		return
+	}
+
+	var missing float64
+	rows := make([]table.Row, 0, len(gaps))
+	for _, gap := range gaps {
+		status := "under-filled"
+		if gap.Logged == 0 {
+			status = "empty"
+		}
+
+		rows = append(rows, table.Row{gap.Date.Format(client.DayDateFormat), formatFloat(gap.Logged),
+			formatFloat(gap.Expected), formatFloat(gap.Missing), status})
+		missing += gap.Missing
+	}
+
+	render(gaps, table.Row{"Date", "Logged", "Expected", "Missing", "Status"}, rows)
+
+	if out.IsTable() {
+		fmt.Printf("%v working days with %v hours missing.\n", len(gaps), formatFloat(missing))
+	}
+}
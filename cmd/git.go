@@ -0,0 +1,304 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/mightymatth/arcli/config"
+	"github.com/mightymatth/arcli/git"
+	"github.com/mightymatth/arcli/utils"
+	"github.com/spf13/cobra"
+)
+
+var hooksForce bool
+
+const (
+	// hookMarker is the line identifying hooks installed by arcli.
+	hookMarker = "# installed by arcli"
+	// hookModeFail makes commit-msg hook reject commits referencing closed or unknown issues.
+	hookModeFail = "fail"
+	// hookModeWarn makes commit-msg hook only warn about commits referencing closed or unknown issues.
+	hookModeWarn = "warn"
+)
+
+var hookModes = []string{hookModeFail, hookModeWarn}
+
+// hooks are names of installed git hooks and arcli commands they run.
+var hooks = map[string]string{
+	"prepare-commit-msg": `git prepare-commit-msg "$@"`,
+	"commit-msg":         `git commit-msg "$1"`,
+}
+
+func newGitCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "git",
+		Short: "Git integration of issue references in commit messages",
+	}
+
+	c.AddCommand(newGitInstallHooksCmd())
+	c.AddCommand(newGitUninstallHooksCmd())
+	c.AddCommand(newGitPrepareCommitMsgCmd())
+	c.AddCommand(newGitCommitMsgCmd())
+
+	return c
+}
+
+func newGitInstallHooksCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "install-hooks",
+		Args:  cobra.NoArgs,
+		Short: "Install git hooks that add and verify issue references in commit messages",
+		Long: fmt.Sprintf(`Install git hooks to the current repository:
+  prepare-commit-msg  prepends 'refs #ID' to commit message, if it does not reference any issue
+                      and the issue ID can be inferred from the branch name (e.g. 'feature/20123-foo')
+  commit-msg          verifies that issues referenced in commit message exist and are open
+
+Commits referencing closed or unknown issues are rejected, or only warned about if
+%v default is set to '%v'.`, config.HookMode, hookModeWarn),
+		Run: func(cmd *cobra.Command, args []string) {
+			dir, err := git.HooksDir(".")
+			if err != nil {
+				fmt.Println("Cannot find git hooks directory:", err)
+				return
+			}
+
+			executable, err := os.Executable()
+			if err != nil {
+				executable = "arcli"
+			}
+
+			for _, name := range sortedKeys(hooks) {
+				file := filepath.Join(dir, name)
+				if !hooksForce && fileExists(file) && !isArcliHook(file) {
+					fmt.Printf("Hook '%v' already exists, use --force to overwrite it.\n", file)
+					continue
+				}
+
+				script := fmt.Sprintf("#!/bin/sh\n%v\nexec %v %v\n", hookMarker,
+					shellQuote(executable), hooks[name])
+
+				err = os.MkdirAll(dir, 0755)
+				if err == nil {
+					err = os.WriteFile(file, []byte(script), 0755)
+				}
+				if err != nil {
+					fmt.Printf("Cannot install hook '%v': %v\n", name, err)
+					continue
+				}
+				fmt.Printf("Hook '%v' has been installed.\n", file)
+			}
+		},
+	}
+
+	c.Flags().BoolVarP(&hooksForce, "force", "f", false,
+		"Overwrite existing hooks")
+
+	return c
+}
+
+func newGitUninstallHooksCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "uninstall-hooks",
+		Args:  cobra.NoArgs,
+		Short: "Remove git hooks installed by arcli",
+		Run: func(cmd *cobra.Command, args []string) {
+			dir, err := git.HooksDir(".")
+			if err != nil {
+				fmt.Println("Cannot find git hooks directory:", err)
+				return
+			}
+
+			for _, name := range sortedKeys(hooks) {
+				file := filepath.Join(dir, name)
+				if !fileExists(file) {
+					continue
+				}
+				if !isArcliHook(file) {
+					fmt.Printf("Hook '%v' was not installed by arcli, so it is kept.\n", file)
+					continue
+				}
+
+				err = os.Remove(file)
+				if err != nil {
+					fmt.Printf("Cannot remove hook '%v': %v\n", name, err)
+					continue
+				}
+				fmt.Printf("Hook '%v' has been removed.\n", file)
+			}
+		},
+	}
+}
+
+func newGitPrepareCommitMsgCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:           "prepare-commit-msg [file] [source] [commit]",
+		Args:          cobra.RangeArgs(1, 3),
+		Hidden:        true,
+		Short:         "Prepend issue reference inferred from branch name to commit message",
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// messages of merges, squashes and amended commits are kept as they are
+			if len(args) > 1 && args[1] != "message" && args[1] != "template" {
+				return nil
+			}
+
+			message, err := os.ReadFile(args[0])
+			if err != nil {
+				fmt.Println("Cannot read commit message:", err)
+				return exitError{code: 1}
+			}
+			if len(git.MessageIssues(utils.StripComments(string(message)))) != 0 {
+				return nil
+			}
+
+			branch, err := git.CurrentBranch(".")
+			if err != nil {
+				return nil
+			}
+			pattern, err := branchPattern()
+			if err != nil {
+				fmt.Println(err)
+				return exitError{code: 1}
+			}
+			id, found := branchIssueID(branch, pattern)
+			if !found {
+				return nil
+			}
+
+			err = os.WriteFile(args[0], append([]byte(fmt.Sprintf("refs #%v ", id)), message...), 0644)
+			if err != nil {
+				fmt.Println("Cannot write commit message:", err)
+				return exitError{code: 1}
+			}
+
+			return nil
+		},
+	}
+}
+
+func newGitCommitMsgCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:           "commit-msg [file]",
+		Args:          cobra.ExactArgs(1),
+		Hidden:        true,
+		Short:         "Verify that issues referenced in commit message exist and are open",
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			message, err := os.ReadFile(args[0])
+			if err != nil {
+				fmt.Println("Cannot read commit message:", err)
+				return exitError{code: 1}
+			}
+
+			problems := verifyIssueReferences(git.MessageIssues(utils.StripComments(string(message))))
+			if len(problems) == 0 {
+				return nil
+			}
+
+			if config.Defaults()[string(config.HookMode)] == hookModeWarn {
+				for _, problem := range problems {
+					fmt.Println("arcli warning:", problem)
+				}
+				return nil
+			}
+
+			for _, problem := range problems {
+				fmt.Println("arcli:", problem)
+			}
+			fmt.Printf("Commit rejected (use 'git commit --no-verify' to skip the check, "+
+				"or 'arcli defaults set %v %v' to only warn).\n", config.HookMode, hookModeWarn)
+			return exitError{code: 1}
+		},
+	}
+}
+
+// verifyIssueReferences returns descriptions of problems with referenced
+// issues that are closed or cannot be found.
+func verifyIssueReferences(ids []int64) []string {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	statuses, err := RClient.GetIssueStatuses(ctx)
+	if err != nil {
+		return []string{fmt.Sprintf("cannot get issue statuses: %v", err)}
+	}
+	closed := make(map[int64]bool, len(statuses))
+	for _, status := range statuses {
+		closed[status.ID] = status.IsClosed
+	}
+
+	var problems []string
+	for _, id := range ids {
+		issue, err := RClient.GetIssue(ctx, id)
+		if err != nil {
+			problems = append(problems, describeError(err, fmt.Sprintf("issue #%v", id)))
+			continue
+		}
+		if closed[issue.Status.ID] {
+			problems = append(problems, fmt.Sprintf("issue #%v '%v' is closed (%v)", id, issue.Subject,
+				issue.Status.Name))
+		}
+	}
+
+	return problems
+}
+
+// branchPattern returns regular expression matching issue ID in branch names,
+// which is set in defaults or the default one.
+func branchPattern() (*regexp.Regexp, error) {
+	pattern := config.Defaults()[string(config.BranchPattern)]
+	if pattern == "" {
+		pattern = git.DefaultBranchPattern
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %v default: %v", config.BranchPattern, err)
+	}
+
+	return re, nil
+}
+
+// branchIssueID returns ID of issue in branch name matched by pattern (e.g.
+// 'feature/20123-foo'), or of the alias that is a part of branch name (e.g. 'feature/login').
+func branchIssueID(branch string, pattern *regexp.Regexp) (int64, bool) {
+	if id, found := git.BranchIssue(branch, pattern); found {
+		return id, true
+	}
+
+	parts := strings.FieldsFunc(branch, func(r rune) bool {
+		return r == '/' || r == '-' || r == '_' || r == '.'
+	})
+	for _, part := range parts {
+		if value, found := config.GetAlias(part); found {
+			if id, err := strconv.ParseInt(value, 10, 64); err == nil {
+				return id, true
+			}
+		}
+	}
+
+	return 0, false
+}
+
+// shellQuote quotes value for POSIX shell, so that none of its characters is
+// interpreted (e.g. '$' or '`' in paths).
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+func isArcliHook(file string) bool {
+	content, err := os.ReadFile(file)
+	return err == nil && strings.Contains(string(content), hookMarker)
+}
+
+func fileExists(file string) bool {
+	_, err := os.Stat(file)
+	return err == nil
+}
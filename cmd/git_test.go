@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/mightymatth/arcli/config"
+	"github.com/spf13/viper"
+)
+
+func TestBranchIssueID(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		branch  string
+		want    int64
+		found   bool
+	}{
+		{"default", "", "feature/1234-foo", 1234, true},
+		{"default", "", "1234", 1234, true},
+		{"default", "", "fix/1234_login", 1234, true},
+		{"default", "", "feature/foo", 0, false},
+		{"default", "", "main", 0, false},
+		{"default", "", "feature/login", 20123, true},
+		{"default", "", "feature/login-form", 20123, true},
+		{"default", "", "feature/1234-login", 1234, true},
+		{"default", "", "feature/docs", 0, false},
+
+		{"override", `^PROJ-(\d+)`, "PROJ-77-foo", 77, true},
+		{"override", `^PROJ-(\d+)`, "feature/1234-foo", 0, false},
+		{"override", `^PROJ-(\d+)`, "1234", 0, false},
+		{"override", `^PROJ-(\d+)`, "feature/foo", 0, false},
+		{"override", `^PROJ-(\d+)`, "feature/login", 20123, true},
+		{"override", `#(\d+)$`, "feature/foo#1234", 1234, true},
+	}
+
+	t.Cleanup(viper.Reset)
+	viper.Set(config.Key(config.AliasesMap), map[string]string{
+		"login": "20123",
+		"docs":  "internal-docs",
+	})
+
+	for _, tt := range tests {
+		defaults := map[string]string{}
+		if tt.pattern != "" {
+			defaults[string(config.BranchPattern)] = tt.pattern
+		}
+		viper.Set(config.Key(config.DefaultsMap), defaults)
+
+		pattern, err := branchPattern()
+		if err != nil {
+			t.Fatalf("branchPattern() error = %v", err)
+		}
+
+		got, found := branchIssueID(tt.branch, pattern)
+		if got != tt.want || found != tt.found {
+			t.Errorf("%v pattern: branchIssueID(%q) = %v, %v, want %v, %v", tt.name, tt.branch, got, found,
+				tt.want, tt.found)
+		}
+	}
+}
+
+func TestBranchPatternInvalid(t *testing.T) {
+	t.Cleanup(viper.Reset)
+	viper.Set(config.Key(config.DefaultsMap), map[string]string{string(config.BranchPattern): "feature/(\\d+"})
+
+	if _, err := branchPattern(); err == nil {
+		t.Errorf("branchPattern() error = nil, want error for invalid pattern")
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("sh is not available")
+	}
+
+	for _, value := range []string{"/usr/local/bin/arcli", "/home/john doe/bin/arcli", `/tmp/$HOME/a"b`,
+		"/tmp/it's/arcli", "/tmp/`id`/arcli", `/tmp/back\slash`, "/tmp/new\nline", ""} {
+		output, err := exec.Command(sh, "-c", "printf %s "+shellQuote(value)).Output()
+		if err != nil {
+			t.Errorf("sh cannot run quoted %q: %v", value, err)
+			continue
+		}
+		if string(output) != value {
+			t.Errorf("shellQuote(%q) = %v, which shell reads as %q", value, shellQuote(value), output)
+		}
+	}
+}
@@ -0,0 +1,488 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/jedib0t/go-pretty/table"
+	"github.com/mightymatth/arcli/client"
+	"github.com/mightymatth/arcli/config"
+	"github.com/mightymatth/arcli/utils"
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+)
+
+var (
+	importDryRun      bool
+	importMapping     map[string]string
+	importRejectsFile string
+	importConcurrency int
+)
+
+const (
+	importDate     = "date"
+	importHours    = "hours"
+	importIssue    = "issue"
+	importProject  = "project"
+	importActivity = "activity"
+	importComment  = "comment"
+	// importError is the column added to rejected rows. It is ignored on import,
+	// so reject files can be fixed and imported again.
+	importError = "error"
+)
+
+// importColumns maps fields to column names (case-insensitive) recognized in
+// imported files.
+var importColumns = map[string][]string{
+	importDate:     {"date", "spent_on", "spent on", "day"},
+	importHours:    {"hours", "time", "duration", "spent"},
+	importIssue:    {"issue", "issue_id", "issue id"},
+	importProject:  {"project", "project_id", "project id"},
+	importActivity: {"activity"},
+	importComment:  {"comment", "comments", "message", "description"},
+}
+
+// importRow is a single row of imported file.
+type importRow struct {
+	line   int
+	values map[string]string
+	// raw keeps the original record (with CSV header), so it can be written to
+	// the reject file.
+	raw    interface{}
+	header []string
+}
+
+// importResult represents validation and submission result of a single row.
+type importResult struct {
+	Line     int                  `json:"line"`
+	Entry    client.TimeEntryPost `json:"entry"`
+	EntryID  int64                `json:"entry_id,omitempty"`
+	Activity string               `json:"activity"`
+	Error    string               `json:"error,omitempty"`
+
+	row      importRow
+	rejected bool
+}
+
+func newTimeEntriesImportCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "import [file]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Import time entries from CSV or JSON file",
+		Long: `Import time entries from CSV file with header row, or JSON file with array of objects.
+Recognized columns are date, hours, issue, project, activity and comment (other columns
+are ignored). Every row is validated before anything is sent. Rows rejected by Redmine
+are written to a reject file, which can be fixed and imported again.`,
+		Example: `arcli log import entries.csv --dry-run
+arcli log import entries.csv --map "hours=Time spent" --map "comment=What"`,
+		Run: timeEntriesImportFunc,
+	}
+
+	c.Flags().BoolVar(&importDryRun, "dry-run", false,
+		"Validate and print time entries without creating them")
+	c.Flags().StringToStringVar(&importMapping, "map", nil,
+		"Column name for field (e.g. 'hours=Time spent')")
+	c.Flags().StringVar(&importRejectsFile, "rejects", "",
+		"File for rows rejected by Redmine (default '[file].rejects.[ext]')")
+	c.Flags().IntVarP(&importConcurrency, "concurrency", "j", 4,
+		"Number of time entries created in parallel")
+
+	return c
+}
+
+func timeEntriesImportFunc(_ *cobra.Command, args []string) {
+	file := args[0]
+
+	for field := range importMapping {
+		if _, found := importColumns[field]; !found {
+			fmt.Printf("Invalid field '%v' in mapping (allowed ones: [%v])\n",
+				field, strings.Join(sortedKeys(importColumns), ", "))
+			return
+		}
+	}
+
+	rows, err := readImportFile(file)
+	if err != nil {
+		fmt.Println("Cannot read import file:", err)
+		return
+	}
+	if len(rows) == 0 {
+		fmt.Println("There are no rows to import.")
+		return
+	}
+
+	results, valid, err := validateImportRows(rows)
+	if err != nil {
+		fmt.Println("Cannot get time entry activities:", describeError(err, "time entry activities"))
+		return
+	}
+	if !valid {
+		if out.IsTable() {
+			fmt.Println("Nothing has been imported, fix the following rows first:")
+		}
+		drawImportResults(results, true)
+		return
+	}
+
+	if importDryRun {
+		if out.IsTable() {
+			fmt.Printf("All %v rows are valid (dry run, nothing has been imported).\n", len(results))
+		}
+		drawImportResults(results, false)
+		return
+	}
+
+	submitImportResults(results)
+	drawImportResults(results, false)
+
+	failed, rejected := 0, make([]importResult, 0)
+	for _, result := range results {
+		if result.Error != "" {
+			failed++
+		}
+		if result.rejected {
+			rejected = append(rejected, result)
+		}
+	}
+
+	if len(rejected) != 0 {
+		rejectsFile := importRejectsFile
+		if rejectsFile == "" {
+			ext := filepath.Ext(file)
+			rejectsFile = strings.TrimSuffix(file, ext) + ".rejects" + ext
+		}
+
+		err = writeImportRejects(file, rejectsFile, rejected)
+		if err != nil {
+			fmt.Println("Cannot write rejected rows:", err)
+		} else if out.IsTable() {
+			fmt.Printf("Rows rejected by Redmine are written to %v\n", rejectsFile)
+		}
+	}
+
+	if out.IsTable() {
+		fmt.Printf("%v of %v time entries imported.\n", len(results)-failed, len(results))
+	}
+}
+
+// readImportFile reads rows from CSV file, or JSON file if it has '.json' extension.
+func readImportFile(file string) ([]importRow, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	if strings.EqualFold(filepath.Ext(file), ".json") {
+		return readImportJSON(f)
+	}
+
+	return readImportCSV(f)
+}
+
+func readImportCSV(r io.Reader) ([]importRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	header := records[0]
+	if len(header) != 0 {
+		header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	}
+
+	var rows []importRow
+	for i, record := range records[1:] {
+		if len(strings.Join(record, "")) == 0 {
+			continue
+		}
+
+		values := make(map[string]string, len(header))
+		for j, column := range header {
+			if j < len(record) {
+				values[column] = record[j]
+			}
+		}
+
+		rows = append(rows, importRow{line: i + 2, values: mapImportColumns(values),
+			raw: record, header: header})
+	}
+
+	return rows, nil
+}
+
+func readImportJSON(r io.Reader) ([]importRow, error) {
+	var records []map[string]interface{}
+	decoder := json.NewDecoder(r)
+	// numbers are kept as they are written (e.g. large IDs are not formatted as floats)
+	decoder.UseNumber()
+	err := decoder.Decode(&records)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]importRow, 0, len(records))
+	for i, record := range records {
+		values := make(map[string]string, len(record))
+		for key, value := range record {
+			if value != nil {
+				values[key] = fmt.Sprint(value)
+			}
+		}
+
+		rows = append(rows, importRow{line: i + 1, values: mapImportColumns(values), raw: record})
+	}
+
+	return rows, nil
+}
+
+// mapImportColumns returns values of record keyed by field names.
+func mapImportColumns(record map[string]string) map[string]string {
+	columns := make(map[string]string, len(record))
+	for column, value := range record {
+		columns[strings.ToLower(strings.TrimSpace(column))] = strings.TrimSpace(value)
+	}
+
+	values := make(map[string]string, len(importColumns))
+	for field, names := range importColumns {
+		if column, found := importMapping[field]; found {
+			names = []string{column}
+		}
+
+		for _, name := range names {
+			if value, found := columns[strings.ToLower(name)]; found {
+				values[field] = value
+				break
+			}
+		}
+	}
+
+	return values
+}
+
+// validateImportRows converts rows to time entries. Second value is false if
+// any of the rows is invalid. Error is returned if rows cannot be validated
+// (e.g. activities cannot be fetched).
+func validateImportRows(rows []importRow) ([]importResult, bool, error) {
+	activities, err := RClient.GetActivities(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	defaultActivity := config.Defaults()[string(config.Activity)]
+	projects := make(map[string]int64)
+
+	valid := true
+	results := make([]importResult, 0, len(rows))
+	for _, row := range rows {
+		result := importResult{Line: row.line, row: row}
+
+		err = func() error {
+			v := row.values
+
+			spentOn, err := spentOnParse(v[importDate])
+			if err != nil {
+				return fmt.Errorf("date: %v", err)
+			}
+			result.Entry.SpentOn = *client.NewDateTime(*spentOn)
+
+			hours, err := parseTimesheetHours(v[importHours])
+			if err != nil || hours == 0 {
+				return fmt.Errorf("hours: invalid value '%v'", v[importHours])
+			}
+			result.Entry.Hours = float32(hours)
+
+			switch {
+			case v[importIssue] != "":
+				issue := strings.TrimPrefix(v[importIssue], "#")
+				if alias, found := config.GetAlias(issue); found {
+					issue = alias
+				}
+				issueID, err := strconv.Atoi(issue)
+				if err != nil {
+					return fmt.Errorf("issue: invalid id '%v'", v[importIssue])
+				}
+				result.Entry.IssueID = issueID
+			case v[importProject] != "":
+				projectID, found := projects[v[importProject]]
+				if !found {
+					projectID, err = resolveProjectID(v[importProject])
+					if err != nil {
+						return fmt.Errorf("project: %v", err)
+					}
+					projects[v[importProject]] = projectID
+				}
+				result.Entry.ProjectID = int(projectID)
+			default:
+				return errors.New("either issue or project is required")
+			}
+
+			result.Activity = v[importActivity]
+			if result.Activity == "" {
+				result.Activity = defaultActivity
+			}
+			if result.Activity == "" {
+				return errors.New("activity: provide it in file or set default")
+			}
+			activityID, found := activities.Valid(result.Activity)
+			if !found {
+				return fmt.Errorf("activity: invalid value '%v'", result.Activity)
+			}
+			result.Entry.ActivityID = int(activityID)
+
+			result.Entry.Comments = v[importComment]
+
+			return nil
+		}()
+		if err != nil {
+			result.Error = err.Error()
+			valid = false
+		}
+
+		results = append(results, result)
+	}
+
+	return results, valid, nil
+}
+
+// submitImportResults creates time entries in parallel and sets results.
+func submitImportResults(results []importResult) {
+	var g errgroup.Group
+	if importConcurrency > 0 {
+		g.SetLimit(importConcurrency)
+	}
+
+	var mu sync.Mutex
+	for i := range results {
+		i := i
+		g.Go(func() error {
+			entry, err := RClient.AddTimeEntry(ctx, results[i].Entry)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				results[i].Error = err.Error()
+				// rows rejected by Redmine keep only its messages, so they can be fixed in rejects file
+				var apiErr *client.APIError
+				if errors.As(err, &apiErr) && client.IsValidation(err) {
+					results[i].rejected = true
+					if len(apiErr.Errors) != 0 {
+						results[i].Error = utils.PrintWithDelimiter(apiErr.Errors)
+					}
+				}
+				return nil
+			}
+			results[i].EntryID = entry.ID
+
+			return nil
+		})
+	}
+
+	_ = g.Wait()
+}
+
+func drawImportResults(results []importResult, onlyInvalid bool) {
+	rows := make([]table.Row, 0, len(results))
+	data := make([]importResult, 0, len(results))
+	for _, result := range results {
+		if onlyInvalid && result.Error == "" {
+			continue
+		}
+
+		target := fmt.Sprintf("#%v", result.Entry.IssueID)
+		if result.Entry.IssueID == 0 {
+			target = "-"
+		}
+		project := "-"
+		if result.Entry.ProjectID != 0 {
+			project = strconv.Itoa(result.Entry.ProjectID)
+		}
+
+		status := "OK"
+		switch {
+		case result.Error != "":
+			status = "Error: " + result.Error
+		case result.EntryID != 0:
+			status = fmt.Sprintf("Created (%v)", result.EntryID)
+		}
+
+		rows = append(rows, table.Row{result.Line, result.Entry.SpentOn.Format(client.DateTimeFormat),
+			target, project, formatTimesheetHours(float64(result.Entry.Hours)), result.Activity,
+			result.Entry.Comments, status})
+		data = append(data, result)
+	}
+
+	render(data, table.Row{"Line", "Date", "Issue", "Project", "Hours", "Activity",
+		"Comment", "Result"}, rows)
+}
+
+// writeImportRejects writes rejected rows in the format of imported file, with
+// Redmine errors in additional column.
+func writeImportRejects(file, rejectsFile string, rejected []importResult) error {
+	sort.Slice(rejected, func(i, j int) bool { return rejected[i].Line < rejected[j].Line })
+
+	f, err := os.Create(rejectsFile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if strings.EqualFold(filepath.Ext(file), ".json") {
+		records := make([]map[string]interface{}, 0, len(rejected))
+		for _, result := range rejected {
+			record := result.row.raw.(map[string]interface{})
+			record[importError] = result.Error
+			records = append(records, record)
+		}
+
+		encoder := json.NewEncoder(f)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(records)
+	}
+
+	header := rejected[0].row.header
+	errorColumn := -1
+	for i, column := range header {
+		if strings.EqualFold(column, importError) {
+			errorColumn = i
+		}
+	}
+	if errorColumn == -1 {
+		errorColumn = len(header)
+		header = append(header[:len(header):len(header)], importError)
+	}
+
+	w := csv.NewWriter(f)
+	err = w.Write(header)
+	if err != nil {
+		return err
+	}
+	for _, result := range rejected {
+		record := make([]string, len(header))
+		copy(record, result.row.raw.([]string))
+		record[errorColumn] = result.Error
+
+		err = w.Write(record)
+		if err != nil {
+			return err
+		}
+	}
+
+	w.Flush()
+	return w.Error()
+}
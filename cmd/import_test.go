@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReadImportJSON(t *testing.T) {
+	rows, err := readImportJSON(strings.NewReader(`[
+		{"date": "2026-10-16", "hours": 1.5, "issue": 2012300, "comment": "review"},
+		{"spent_on": "2026-10-17", "time": "0:45", "project_id": 12, "activity": null}
+	]`))
+	if err != nil {
+		t.Fatalf("readImportJSON() error = %v", err)
+	}
+
+	tests := []struct {
+		row   int
+		field string
+		want  string
+	}{
+		{0, importDate, "2026-10-16"},
+		{0, importHours, "1.5"},
+		{0, importIssue, "2012300"},
+		{0, importComment, "review"},
+		{1, importDate, "2026-10-17"},
+		{1, importHours, "0:45"},
+		{1, importProject, "12"},
+		{1, importActivity, ""},
+	}
+
+	for _, tt := range tests {
+		if got := rows[tt.row].values[tt.field]; got != tt.want {
+			t.Errorf("row %v %v = %q, want %q", tt.row+1, tt.field, got, tt.want)
+		}
+	}
+}
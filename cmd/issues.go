@@ -2,8 +2,11 @@ package cmd
 
 import (
 	"fmt"
-	"log"
+	"os"
 	"strconv"
+	"strings"
+
+	"github.com/spf13/viper"
 
 	"github.com/mightymatth/arcli/config"
 
@@ -25,6 +28,12 @@ func newIssuesCmd() *cobra.Command {
 		Run:     issueFunc,
 	}
 
+	c.Flags().StringSliceVar(&issueShowSections, "show", defaultIssueSections,
+		fmt.Sprintf("Sections of issue details to show (%v)",
+			strings.Join(append(issueSections, sectionAll), ", ")))
+
+	c.AddCommand(newIssueCreateCmd())
+	c.AddCommand(newIssueUpdateCmd())
 	c.AddCommand(newMyIssuesCmd())
 	c.AddCommand(newMyRelatedIssuesCmd())
 	c.AddCommand(newMyWatchedIssuesCmd())
@@ -32,13 +41,26 @@ func newIssuesCmd() *cobra.Command {
 	return c
 }
 
+// validIssueArgs validates issue ID or alias given as argument. If it is omitted,
+// the current issue is resolved from git branch name or .arcli file.
 func validIssueArgs() cobra.PositionalArgs {
 	return func(cmd *cobra.Command, args []string) error {
-		err := cobra.ExactArgs(1)(cmd, args)
+		err := cobra.MaximumNArgs(1)(cmd, args)
 		if err != nil {
 			return err
 		}
 
+		if len(args) == 0 {
+			id, source, err := currentIssue()
+			if err != nil {
+				return err
+			}
+
+			contextIssueID = id
+			fmt.Fprintf(os.Stderr, "Using issue #%v from %v.\n", id, source)
+			return nil
+		}
+
 		val, found := config.GetAlias(args[0])
 		if found {
 			args[0] = val
@@ -53,17 +75,405 @@ func validIssueArgs() cobra.PositionalArgs {
 	}
 }
 
+// resolveIssueID returns issue ID given directly or by alias.
+func resolveIssueID(issue string) (int64, error) {
+	if val, found := config.GetAlias(issue); found {
+		issue = val
+	}
+
+	id, err := strconv.ParseInt(issue, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("issue id must be integer, but given %v", issue)
+	}
+
+	return id, nil
+}
+
+var issueShowSections []string
+
 func issueFunc(_ *cobra.Command, args []string) {
-	issueID, _ := strconv.ParseInt(args[0], 10, 64)
-	issue, err := RClient.GetIssue(issueID)
+	err := validIssueSections(issueShowSections)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	issueID := issueArgID(args)
+	issue, err := RClient.GetIssue(ctx, issueID)
+	if err != nil {
+		fmt.Println("Cannot fetch issue:", describeError(err, fmt.Sprintf("issue %v", issueID)))
+		return
+	}
+
+	if !out.IsTable() {
+		drawIssueDetails(issue)
+		return
+	}
+
+	drawIssue(issue, issueShowSections)
+}
+
+var (
+	issueProject, issueTracker, issueSubject, issueDescription string
+	issuePriority, issueAssignee, issueStartDate, issueDueDate string
+	issueStatus, issueVersion, issueNote                       string
+	issueParentID                                              int64
+	issueDoneRatio                                             int
+	issueEstimatedHours                                        float64
+	issueCustomFields                                          []string
+)
+
+func newIssueCreateCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:     "new",
+		Aliases: []string{"create", "add"},
+		Args:    cobra.ExactArgs(0),
+		Short:   "Create new issue",
+		Long: `Create new issue. If description is not provided, it is written in the editor
+set by VISUAL or EDITOR environment variable.`,
+		Run: issueCreateFunc,
+	}
+
+	c.Flags().StringVarP(&issueProject, "project", "p", "",
+		"Project ID, identifier or alias")
+	c.Flags().StringVarP(&issueSubject, "subject", "s", "",
+		"Issue subject")
+	c.Flags().StringVarP(&issueDescription, "description", "d", "",
+		"Issue description")
+	c.Flags().StringVar(&issueTracker, "tracker", "",
+		"The name of tracker (e.g. 'Bug'; project default if not set)")
+	c.Flags().StringVar(&issuePriority, "priority", "",
+		"The name of priority (e.g. 'High'; server default if not set)")
+	c.Flags().StringVar(&issueAssignee, "assignee", "",
+		"Assignee ('me', user login or ID)")
+	c.Flags().Int64Var(&issueParentID, "parent", 0,
+		"Parent issue ID")
+	c.Flags().StringVar(&issueStartDate, "start", "",
+		"Start date ('today', 'yesterday', '2020-01-15')")
+	c.Flags().StringVar(&issueDueDate, "due", "",
+		"Due date ('today', 'yesterday', '2020-01-15')")
+	c.Flags().Float64VarP(&issueEstimatedHours, "estimated", "e", 0,
+		"Estimated time in hours")
+	c.Flags().StringArrayVar(&issueCustomFields, "custom-field", nil,
+		"Custom field value in form of 'id=value' (can be repeated)")
+	_ = c.MarkFlagRequired("project")
+	_ = c.MarkFlagRequired("subject")
+
+	return c
+}
+
+func issueCreateFunc(cmd *cobra.Command, _ []string) {
+	var err error
+	var issuePost client.IssuePost
+	issuePost.Subject = issueSubject
+	issuePost.ParentIssueID = issueParentID
+	issuePost.EstimatedHours = issueEstimatedHours
+
+	issuePost.ProjectID, err = resolveProjectID(issueProject)
+	if err != nil {
+		fmt.Println("Cannot find project:", err)
+		return
+	}
+
+	if issueTracker != "" {
+		issuePost.TrackerID, err = resolveTrackerID(issueTracker)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+	}
+
+	if issuePriority != "" {
+		issuePost.PriorityID, err = resolvePriorityID(issuePriority)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+	}
+
+	if issueAssignee != "" {
+		issuePost.AssignedToID, err = resolveUserID(issueAssignee)
+		if err != nil {
+			fmt.Println("Cannot find assignee:", err)
+			return
+		}
+	}
+
+	if issueStartDate != "" {
+		startDate, err := spentOnParse(issueStartDate)
+		if err != nil {
+			fmt.Println("Cannot parse start date:", err)
+			return
+		}
+		issuePost.StartDate = client.NewDateTime(*startDate)
+	}
+
+	if issueDueDate != "" {
+		dueDate, err := spentOnParse(issueDueDate)
+		if err != nil {
+			fmt.Println("Cannot parse due date:", err)
+			return
+		}
+		issuePost.DueDate = client.NewDateTime(*dueDate)
+	}
+
+	issuePost.CustomFields, err = parseCustomFields(issueCustomFields)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	if cmd.Flags().Changed("description") {
+		issuePost.Description = issueDescription
+	} else {
+		issuePost.Description, err = editDescription(issueSubject)
+		if err != nil {
+			fmt.Println("Cannot edit description:", err)
+			return
+		}
+	}
+
+	issue, err := RClient.CreateIssue(ctx, issuePost)
+	if err != nil {
+		fmt.Println("Cannot create issue:", describeError(err, ""))
+		return
+	}
+
+	drawIssueSummary(issue, "Issue created!")
+}
+
+func newIssueUpdateCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:     "update [id]",
+		Args:    validIssueArgs(),
+		Aliases: []string{"u", "edit", "modify"},
+		Short:   "Update issue status, assignee, done ratio, priority or target version",
+		Run:     issueUpdateFunc,
+	}
+
+	c.Flags().StringVarP(&issueStatus, "status", "s", "",
+		"The name of new status (e.g. 'Resolved')")
+	c.Flags().StringVar(&issueAssignee, "assignee", "",
+		"Assignee ('me', user login or ID)")
+	c.Flags().IntVar(&issueDoneRatio, "done", 0,
+		"Done ratio in percents (0-100)")
+	c.Flags().StringVar(&issuePriority, "priority", "",
+		"The name of priority (e.g. 'High')")
+	c.Flags().StringVar(&issueVersion, "version", "",
+		"Target version name or ID")
+	c.Flags().StringVarP(&issueNote, "note", "n", "",
+		"Note added to issue history")
+
+	return c
+}
+
+func issueUpdateFunc(cmd *cobra.Command, args []string) {
+	issueID := issueArgID(args)
+
+	if !anyFlagChanged(cmd, "status", "assignee", "done", "priority", "version", "note") {
+		fmt.Println("Nothing to update, provide at least one of the flags.")
+		return
+	}
+
+	var err error
+	var issuePost client.IssuePost
+	issuePost.Notes = issueNote
+
+	if issueStatus != "" {
+		statuses, err := RClient.GetIssueStatuses(ctx)
+		if err != nil {
+			fmt.Println("Cannot get issue statuses:", err)
+			return
+		}
+
+		statusID, exists := statuses.Valid(issueStatus)
+		if !exists {
+			fmt.Printf("Invalid status (allowed ones: [%v])\n",
+				utils.PrintWithDelimiter(statuses.Names()))
+			return
+		}
+		issuePost.StatusID = statusID
+	}
+
+	if issueAssignee != "" {
+		issuePost.AssignedToID, err = resolveUserID(issueAssignee)
+		if err != nil {
+			fmt.Println("Cannot find assignee:", err)
+			return
+		}
+	}
+
+	if cmd.Flags().Changed("done") {
+		if issueDoneRatio < 0 || issueDoneRatio > 100 {
+			fmt.Println("Done ratio must be between 0 and 100.")
+			return
+		}
+		issuePost.DoneRatio = &issueDoneRatio
+	}
+
+	if issuePriority != "" {
+		issuePost.PriorityID, err = resolvePriorityID(issuePriority)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+	}
+
+	if issueVersion != "" {
+		issuePost.FixedVersionID, err = resolveVersionID(issueID, issueVersion)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+	}
+
+	err = RClient.UpdateIssue(ctx, issueID, issuePost)
+	if err != nil {
+		fmt.Println("Cannot update issue:", describeError(err, fmt.Sprintf("issue %v", issueID)))
+		return
+	}
+
+	issue, err := RClient.GetIssue(ctx, issueID)
 	if err != nil {
-		fmt.Printf("Cannot fetch issue with id %v\n", issueID)
+		fmt.Printf("Issue updated, but it cannot be fetched: %v\n", err)
 		return
 	}
-	project := client.Project{ID: issue.Project.ID, Name: issue.Project.Name}
-	fmt.Printf("[%v] %v (%v)\n", text.FgYellow.Sprint(project.ID), text.FgYellow.Sprint(project.Name), project.URL())
-	fmt.Printf("  [%v] %v (%v)\n", text.FgGreen.Sprint(issue.ID), text.FgGreen.Sprint(issue.Subject), issue.URL())
-	fmt.Printf("%v\n", issue.Description)
+
+	drawIssueSummary(issue, "Issue updated!")
+}
+
+func resolveTrackerID(name string) (int64, error) {
+	trackers, err := RClient.GetTrackers(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("cannot get trackers: %v", err)
+	}
+
+	trackerID, exists := trackers.Valid(name)
+	if !exists {
+		return 0, fmt.Errorf("invalid tracker (allowed ones: [%v])",
+			utils.PrintWithDelimiter(trackers.Names()))
+	}
+
+	return trackerID, nil
+}
+
+func resolvePriorityID(name string) (int64, error) {
+	priorities, err := RClient.GetIssuePriorities(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("cannot get issue priorities: %v", err)
+	}
+
+	priorityID, exists := priorities.Valid(name)
+	if !exists {
+		return 0, fmt.Errorf("invalid priority (allowed ones: [%v])",
+			utils.PrintWithDelimiter(priorities.Names()))
+	}
+
+	return priorityID, nil
+}
+
+// resolveVersionID returns ID of version given as ID or name of a version
+// available to the project of issue with given ID.
+func resolveVersionID(issueID int64, version string) (int64, error) {
+	id, err := strconv.ParseInt(version, 10, 64)
+	if err == nil {
+		return id, nil
+	}
+
+	issue, err := RClient.GetIssue(ctx, issueID)
+	if err != nil {
+		return 0, fmt.Errorf("cannot fetch issue: %v", describeError(err, fmt.Sprintf("issue %v", issueID)))
+	}
+
+	versions, err := RClient.GetProjectVersions(ctx, issue.Project.ID)
+	if err != nil {
+		return 0, fmt.Errorf("cannot get project versions: %v", err)
+	}
+
+	versionID, exists := versions.Valid(version)
+	if !exists {
+		return 0, fmt.Errorf("invalid version (allowed ones: [%v])",
+			utils.PrintWithDelimiter(versions.Names()))
+	}
+
+	return versionID, nil
+}
+
+func editDescription(subject string) (string, error) {
+	content := fmt.Sprintf("\n# Write description of issue '%v'.\n"+
+		"# Lines starting with '#' will be ignored.\n", subject)
+
+	edited, err := utils.EditText(content, "*.md")
+	if err != nil {
+		return "", err
+	}
+
+	return utils.StripComments(edited), nil
+}
+
+// resolveUserID returns ID of user given as 'me', login or ID.
+func resolveUserID(user string) (int64, error) {
+	if user == "me" {
+		return viper.GetInt64(config.Key(config.UserID)), nil
+	}
+
+	id, err := strconv.ParseInt(user, 10, 64)
+	if err == nil {
+		return id, nil
+	}
+
+	found, err := RClient.GetUserByLogin(ctx, user)
+	if err != nil {
+		return 0, err
+	}
+
+	return found.ID, nil
+}
+
+// issueSubjects returns subjects of issues with given IDs. Subjects are only used
+// as hints, so issues that cannot be fetched are left out.
+func issueSubjects(ids []int64) map[int64]string {
+	subjects := make(map[int64]string, len(ids))
+
+	var batch []string
+	for i, id := range ids {
+		if _, found := subjects[id]; !found {
+			subjects[id] = ""
+			batch = append(batch, strconv.FormatInt(id, 10))
+		}
+
+		// long lists of IDs are split to keep request URLs short
+		if len(batch) == client.PageSize || (i == len(ids)-1 && len(batch) != 0) {
+			issues, err := RClient.GetIssues(ctx, fmt.Sprintf("issue_id=%v&status_id=*", strings.Join(batch, ",")))
+			if err == nil {
+				for _, issue := range issues {
+					subjects[issue.ID] = issue.Subject
+				}
+			}
+			batch = batch[:0]
+		}
+	}
+
+	return subjects
+}
+
+func parseCustomFields(fields []string) ([]client.CustomFieldValue, error) {
+	values := make([]client.CustomFieldValue, 0, len(fields))
+	for _, field := range fields {
+		parts := strings.SplitN(field, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("custom field must be in form of 'id=value', but given '%v'", field)
+		}
+
+		id, err := strconv.ParseInt(parts[0], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("custom field id must be integer, but given '%v'", parts[0])
+		}
+
+		values = append(values, client.CustomFieldValue{ID: id, Value: parts[1]})
+	}
+
+	return values, nil
 }
 
 func newMyIssuesCmd() *cobra.Command {
@@ -72,13 +482,27 @@ func newMyIssuesCmd() *cobra.Command {
 		Aliases: []string{"assigned", "list", "ls"},
 		Short:   "List all issues assigned to the user",
 		Run: func(cmd *cobra.Command, args []string) {
-			issues, err := RClient.GetMyIssues()
-			if err != nil {
-				fmt.Println("Cannot fetch my issues:", err)
+			userID := viper.GetInt64(config.Key(config.UserID))
+			if !out.IsTable() {
+				issues, err := RClient.GetUserIssues(ctx, userID)
+				if err != nil {
+					fmt.Println("Cannot fetch my issues:", describeError(err, ""))
+					return
+				}
+
+				drawIssues(issues)
 				return
 			}
 
-			drawIssues(issues)
+			first := true
+			err := RClient.StreamIssues(ctx, fmt.Sprintf("assigned_to_id=%v", userID), func(issues []client.Issue) error {
+				renderPage(issuesHeader, issueRows(issues), first)
+				first = false
+				return nil
+			})
+			if err != nil {
+				fmt.Println("Cannot fetch my issues:", describeError(err, ""))
+			}
 		},
 	}
 
@@ -91,7 +515,7 @@ func newMyRelatedIssuesCmd() *cobra.Command {
 		Aliases: []string{"rel"},
 		Short:   "List all related issues assigned to the user (including groups)",
 		Run: func(cmd *cobra.Command, args []string) {
-			issues, err := RClient.GetMyRelatedIssues()
+			issues, err := RClient.GetMyRelatedIssues(ctx)
 			if err != nil {
 				fmt.Println("Cannot fetch my related issues:", err)
 				return
@@ -110,9 +534,9 @@ func newMyWatchedIssuesCmd() *cobra.Command {
 		Aliases: []string{"w"},
 		Short:   "List all issues watched by the user",
 		Run: func(cmd *cobra.Command, args []string) {
-			issues, err := RClient.GetMyWatchedIssues()
+			issues, err := RClient.GetMyWatchedIssues(ctx)
 			if err != nil {
-				log.Println("Cannot fetch watched issues:", err)
+				fmt.Println("Cannot fetch watched issues:", err)
 				return
 			}
 
@@ -123,12 +547,28 @@ func newMyWatchedIssuesCmd() *cobra.Command {
 	return c
 }
 
+var issuesHeader = table.Row{"ID", "Project", "Subject", "URL"}
+
 func drawIssues(issues []client.Issue) {
-	t := utils.NewTable()
-	t.AppendHeader(table.Row{"ID", "Project", "Subject", "URL"})
+	render(issues, issuesHeader, issueRows(issues))
+}
+
+func issueRows(issues []client.Issue) []table.Row {
+	rows := make([]table.Row, 0, len(issues))
 	for _, issue := range issues {
-		t.AppendRow(table.Row{issue.ID, issue.Project.Name, issue.Subject, issue.URL()})
+		rows = append(rows, table.Row{issue.ID, issue.Project.Name, issue.Subject, RClient.IssueURL(issue.ID)})
+	}
+
+	return rows
+}
+
+// drawIssueSummary prints issue ID, subject and URL after the issue has been changed.
+func drawIssueSummary(issue *client.Issue, message string) {
+	if !out.IsTable() {
+		drawIssues([]client.Issue{*issue})
+		return
 	}
 
-	t.Render()
+	fmt.Println(message)
+	fmt.Printf("[%v] %v (%v)\n", text.FgGreen.Sprint(issue.ID), text.FgGreen.Sprint(issue.Subject), RClient.IssueURL(issue.ID))
 }
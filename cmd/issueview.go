@@ -0,0 +1,463 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/table"
+	"github.com/jedib0t/go-pretty/text"
+	"github.com/mightymatth/arcli/client"
+	"github.com/mightymatth/arcli/utils"
+)
+
+// Sections of issue detail view.
+const (
+	sectionHeader      = "header"
+	sectionDescription = "description"
+	sectionHistory     = "history"
+	sectionSubtasks    = "subtasks"
+	sectionRelations   = "relations"
+	sectionAttachments = "attachments"
+	sectionWatchers    = "watchers"
+	sectionChangesets  = "changesets"
+	sectionAll         = "all"
+)
+
+var issueSections = []string{sectionHeader, sectionDescription, sectionHistory, sectionSubtasks,
+	sectionRelations, sectionAttachments, sectionWatchers, sectionChangesets}
+
+var defaultIssueSections = []string{sectionHeader, sectionDescription, sectionHistory, sectionSubtasks,
+	sectionRelations, sectionAttachments}
+
+var journalAttrLabels = map[string]string{
+	"project_id":       "Project",
+	"tracker_id":       "Tracker",
+	"status_id":        "Status",
+	"priority_id":      "Priority",
+	"assigned_to_id":   "Assignee",
+	"fixed_version_id": "Target version",
+	"category_id":      "Category",
+	"parent_id":        "Parent task",
+	"subject":          "Subject",
+	"description":      "Description",
+	"done_ratio":       "% Done",
+	"start_date":       "Start date",
+	"due_date":         "Due date",
+	"estimated_hours":  "Estimated time",
+	"is_private":       "Private",
+}
+
+var relationLabels = map[string][2]string{
+	"relates":    {"related to", "related to"},
+	"duplicates": {"duplicates", "duplicated by"},
+	"blocks":     {"blocks", "blocked by"},
+	"precedes":   {"precedes", "follows"},
+	"copied_to":  {"copied to", "copied from"},
+}
+
+func validIssueSections(sections []string) error {
+	for _, section := range sections {
+		if section != sectionAll && !contains(issueSections, section) {
+			return fmt.Errorf("invalid section '%v' (allowed ones: [%v])", section,
+				utils.PrintWithDelimiter(append(issueSections, sectionAll)))
+		}
+	}
+	return nil
+}
+
+func drawIssue(issue *client.Issue, sections []string) {
+	show := func(section string) bool {
+		return contains(sections, section) || contains(sections, sectionAll)
+	}
+
+	if show(sectionHeader) {
+		drawIssueHeader(issue)
+	}
+	if show(sectionDescription) {
+		drawIssueDescription(issue)
+	}
+	if show(sectionHistory) && len(issue.Journals) != 0 {
+		drawIssueHistory(issue)
+	}
+	if show(sectionSubtasks) && len(issue.Children) != 0 {
+		drawIssueSubtasks(issue)
+	}
+	if show(sectionRelations) && len(issue.Relations) != 0 {
+		drawIssueRelations(issue)
+	}
+	if show(sectionAttachments) && len(issue.Attachments) != 0 {
+		drawIssueAttachments(issue)
+	}
+	if show(sectionWatchers) && len(issue.Watchers) != 0 {
+		drawIssueWatchers(issue)
+	}
+	if show(sectionChangesets) && len(issue.Changesets) != 0 {
+		drawIssueChangesets(issue)
+	}
+}
+
+// drawIssueDetails renders issue with all of its fields in non-table output formats.
+func drawIssueDetails(issue *client.Issue) {
+	var assignee, version string
+	if issue.AssignedTo != nil {
+		assignee = issue.AssignedTo.Name
+	}
+	if issue.FixedVersion != nil {
+		version = issue.FixedVersion.Name
+	}
+
+	render(issue,
+		table.Row{"ID", "Project", "Tracker", "Status", "Priority", "Author", "Assignee", "Target version",
+			"Subject", "Start date", "Due date", "% Done", "Estimated time", "Spent time", "URL"},
+		[]table.Row{{issue.ID, issue.Project.Name, issue.Tracker.Name, issue.Status.Name, issue.Priority.Name,
+			issue.Author.Name, assignee, version, issue.Subject, formatDate(issue.StartDate),
+			formatDate(issue.DueDate), issue.DoneRatio, formatHours(issue.EstimatedHours),
+			formatHours(issue.SpentHours), RClient.IssueURL(issue.ID)}})
+}
+
+func drawIssueHeader(issue *client.Issue) {
+	project := client.Project{ID: issue.Project.ID, Name: issue.Project.Name}
+	fmt.Printf("[%v] %v (%v)\n", text.FgYellow.Sprint(project.ID), text.FgYellow.Sprint(project.Name), RClient.ProjectURL(project.ID))
+	fmt.Printf("  [%v] %v %v (%v)\n", text.FgGreen.Sprint(issue.ID), issue.Tracker.Name,
+		text.FgGreen.Sprint(issue.Subject), RClient.IssueURL(issue.ID))
+
+	assignee, version, parent := "-", "-", "-"
+	if issue.AssignedTo != nil {
+		assignee = issue.AssignedTo.Name
+	}
+	if issue.FixedVersion != nil {
+		version = issue.FixedVersion.Name
+	}
+	if issue.Parent != nil {
+		parent = "#" + issue.Parent.String()
+	}
+
+	fields := [][2]string{
+		{"Status", issue.Status.Name},
+		{"Priority", issue.Priority.Name},
+		{"Author", issue.Author.Name},
+		{"Assignee", assignee},
+		{"Target version", version},
+		{"Parent task", parent},
+		{"Start date", formatDate(issue.StartDate)},
+		{"Due date", formatDate(issue.DueDate)},
+		{"% Done", fmt.Sprintf("%d%%", issue.DoneRatio)},
+		{"Estimated time", formatHours(issue.EstimatedHours)},
+		{"Spent time", formatHours(issue.SpentHours)},
+		{"Updated", issue.UpdatedOn.Local().Format(dateTimeMinutesFormat)},
+	}
+	for _, field := range issue.CustomFields {
+		fields = append(fields, [2]string{field.Name, fmt.Sprint(field.Value)})
+	}
+
+	width := 0
+	for _, field := range fields {
+		if len(field[0]) > width {
+			width = len(field[0])
+		}
+	}
+	for _, field := range fields {
+		fmt.Printf("  %v %v\n", text.FgCyan.Sprintf("%-*s", width+1, field[0]+":"), field[1])
+	}
+}
+
+func drawIssueDescription(issue *client.Issue) {
+	description := strings.TrimSpace(strings.ReplaceAll(issue.Description, "\r\n", "\n"))
+	if description == "" {
+		return
+	}
+
+	drawSectionTitle("Description")
+	for _, line := range strings.Split(description, "\n") {
+		fmt.Printf("  %v\n", line)
+	}
+}
+
+func drawIssueHistory(issue *client.Issue) {
+	drawSectionTitle("History")
+	names := newJournalNames(issue)
+
+	for _, journal := range issue.Journals {
+		fmt.Printf("  %v %v %v\n", text.FgYellow.Sprint("●"),
+			journal.CreatedOn.Local().Format(dateTimeMinutesFormat), text.FgYellow.Sprint(journal.User.Name))
+
+		for _, detail := range journal.Details {
+			fmt.Printf("      %v\n", names.describe(detail))
+		}
+
+		notes := strings.TrimSpace(strings.ReplaceAll(journal.Notes, "\r\n", "\n"))
+		if notes != "" {
+			for _, line := range strings.Split(notes, "\n") {
+				fmt.Printf("      %v\n", text.Italic.Sprint(line))
+			}
+		}
+	}
+}
+
+func drawIssueSubtasks(issue *client.Issue) {
+	drawSectionTitle("Subtasks")
+	drawIssueChildren(issue.Children, "  ")
+}
+
+func drawIssueChildren(children []client.IssueChild, indent string) {
+	for i, child := range children {
+		branch, nextIndent := "├ ", "│ "
+		if i == len(children)-1 {
+			branch, nextIndent = "└ ", "  "
+		}
+
+		fmt.Printf("%v%v[%v] %v %v\n", indent, branch, text.FgGreen.Sprint(child.ID),
+			child.Tracker.Name, child.Subject)
+		drawIssueChildren(child.Children, indent+nextIndent)
+	}
+}
+
+func drawIssueRelations(issue *client.Issue) {
+	drawSectionTitle("Related issues")
+
+	ids := make([]string, 0, len(issue.Relations))
+	for _, relation := range issue.Relations {
+		ids = append(ids, strconv.FormatInt(relatedIssueID(issue.ID, relation), 10))
+	}
+
+	subjects := make(map[int64]string)
+	related, err := RClient.GetIssues(ctx, fmt.Sprintf("issue_id=%v&status_id=*", strings.Join(ids, ",")))
+	if err == nil {
+		for _, relatedIssue := range related {
+			subjects[relatedIssue.ID] = fmt.Sprintf("%v (%v)", relatedIssue.Subject, relatedIssue.Status.Name)
+		}
+	}
+
+	for _, relation := range issue.Relations {
+		labels, found := relationLabels[relation.RelationType]
+		if !found {
+			labels = [2]string{relation.RelationType, relation.RelationType}
+		}
+
+		label := labels[0]
+		if relation.IssueToID == issue.ID {
+			label = labels[1]
+		}
+
+		relatedID := relatedIssueID(issue.ID, relation)
+		fmt.Printf("  %-14v [%v] %v\n", label, text.FgGreen.Sprint(relatedID), subjects[relatedID])
+	}
+}
+
+func relatedIssueID(issueID int64, relation client.IssueRelation) int64 {
+	if relation.IssueID == issueID {
+		return relation.IssueToID
+	}
+	return relation.IssueID
+}
+
+func drawIssueAttachments(issue *client.Issue) {
+	drawSectionTitle("Attachments")
+	for _, attachment := range issue.Attachments {
+		fmt.Printf("  %v (%v, %v, %v)\n    %v\n", text.FgGreen.Sprint(attachment.Filename),
+			formatFileSize(attachment.Filesize), attachment.Author.Name,
+			attachment.CreatedOn.Local().Format(dateTimeMinutesFormat), attachment.ContentURL)
+	}
+}
+
+func drawIssueWatchers(issue *client.Issue) {
+	drawSectionTitle("Watchers")
+	for _, watcher := range issue.Watchers {
+		fmt.Printf("  %v\n", watcher.Name)
+	}
+}
+
+func drawIssueChangesets(issue *client.Issue) {
+	drawSectionTitle("Associated revisions")
+	for _, changeset := range issue.Changesets {
+		revision := changeset.Revision
+		if len(revision) > 10 {
+			revision = revision[:10]
+		}
+
+		var author string
+		if changeset.User != nil {
+			author = changeset.User.Name
+		}
+
+		comment := strings.SplitN(strings.TrimSpace(changeset.Comments), "\n", 2)[0]
+		fmt.Printf("  %v %v %v %v\n", text.FgYellow.Sprint(revision),
+			changeset.CommittedOn.Local().Format(dateTimeMinutesFormat), author, comment)
+	}
+}
+
+func drawSectionTitle(title string) {
+	fmt.Printf("\n%v\n", text.Bold.Sprint(title))
+}
+
+// journalNames translates IDs in journal details to names. Enumerations are fetched
+// only if journals contain change of related attribute.
+type journalNames struct {
+	issue  *client.Issue
+	values map[string]map[string]string
+}
+
+func newJournalNames(issue *client.Issue) journalNames {
+	names := journalNames{issue: issue, values: make(map[string]map[string]string)}
+
+	attrs := make(map[string]bool)
+	for _, journal := range issue.Journals {
+		for _, detail := range journal.Details {
+			if detail.Property == "attr" {
+				attrs[detail.Name] = true
+			}
+		}
+	}
+
+	users := make(map[string]string)
+	addUser := func(id int64, name string) {
+		users[strconv.FormatInt(id, 10)] = name
+	}
+	addUser(issue.Author.ID, issue.Author.Name)
+	if issue.AssignedTo != nil {
+		addUser(issue.AssignedTo.ID, issue.AssignedTo.Name)
+	}
+	for _, watcher := range issue.Watchers {
+		addUser(watcher.ID, watcher.Name)
+	}
+	for _, journal := range issue.Journals {
+		addUser(journal.User.ID, journal.User.Name)
+	}
+	names.values["assigned_to_id"] = users
+
+	if attrs["status_id"] {
+		if statuses, err := RClient.GetIssueStatuses(ctx); err == nil {
+			values := make(map[string]string)
+			for _, status := range statuses {
+				values[strconv.FormatInt(status.ID, 10)] = status.Name
+			}
+			names.values["status_id"] = values
+		}
+	}
+
+	if attrs["tracker_id"] {
+		if trackers, err := RClient.GetTrackers(ctx); err == nil {
+			values := make(map[string]string)
+			for _, tracker := range trackers {
+				values[strconv.FormatInt(tracker.ID, 10)] = tracker.Name
+			}
+			names.values["tracker_id"] = values
+		}
+	}
+
+	if attrs["priority_id"] {
+		if priorities, err := RClient.GetIssuePriorities(ctx); err == nil {
+			values := make(map[string]string)
+			for _, priority := range priorities {
+				values[strconv.FormatInt(priority.ID, 10)] = priority.Name
+			}
+			names.values["priority_id"] = values
+		}
+	}
+
+	if attrs["fixed_version_id"] {
+		if versions, err := RClient.GetProjectVersions(ctx, issue.Project.ID); err == nil {
+			values := make(map[string]string)
+			for _, version := range versions {
+				values[strconv.FormatInt(version.ID, 10)] = version.Name
+			}
+			names.values["fixed_version_id"] = values
+		}
+	}
+
+	return names
+}
+
+func (n journalNames) describe(detail client.JournalDetail) string {
+	switch detail.Property {
+	case "attachment":
+		if detail.NewValue != nil {
+			return fmt.Sprintf("File %v added", text.FgGreen.Sprint(*detail.NewValue))
+		}
+		return fmt.Sprintf("File %v deleted", text.FgRed.Sprint(valueOf(detail.OldValue)))
+	case "relation":
+		labels := relationLabels[detail.Name]
+		if detail.NewValue != nil {
+			return fmt.Sprintf("Relation added: %v #%v", labels[0], *detail.NewValue)
+		}
+		return fmt.Sprintf("Relation deleted: %v #%v", labels[0], valueOf(detail.OldValue))
+	case "cf":
+		label := "Custom field " + detail.Name
+		for _, field := range n.issue.CustomFields {
+			if strconv.FormatInt(field.ID, 10) == detail.Name {
+				label = field.Name
+			}
+		}
+		return journalDiff(label, detail.OldValue, detail.NewValue)
+	}
+
+	label, found := journalAttrLabels[detail.Name]
+	if !found {
+		label = detail.Name
+	}
+
+	if detail.Name == "description" {
+		return fmt.Sprintf("%v updated", text.Bold.Sprint(label))
+	}
+
+	values := n.values[detail.Name]
+	translate := func(value *string) *string {
+		if value == nil {
+			return nil
+		}
+		if name, found := values[*value]; found {
+			return &name
+		}
+		return value
+	}
+
+	return journalDiff(label, translate(detail.OldValue), translate(detail.NewValue))
+}
+
+func journalDiff(label string, oldValue, newValue *string) string {
+	switch {
+	case oldValue == nil || *oldValue == "":
+		return fmt.Sprintf("%v set to %v", text.Bold.Sprint(label), text.FgGreen.Sprint(valueOf(newValue)))
+	case newValue == nil || *newValue == "":
+		return fmt.Sprintf("%v deleted (%v)", text.Bold.Sprint(label), text.FgRed.Sprint(*oldValue))
+	default:
+		return fmt.Sprintf("%v: %v → %v", text.Bold.Sprint(label),
+			text.FgRed.Sprint(*oldValue), text.FgGreen.Sprint(*newValue))
+	}
+}
+
+func valueOf(value *string) string {
+	if value == nil {
+		return "-"
+	}
+	return *value
+}
+
+// dateTimeMinutesFormat represents date and time format without seconds.
+const dateTimeMinutesFormat = "2006-01-02 15:04"
+
+func formatDate(date *client.DateTime) string {
+	if date == nil {
+		return "-"
+	}
+	return date.Format(client.DateTimeFormat)
+}
+
+func formatHours(hours *float64) string {
+	if hours == nil {
+		return "-"
+	}
+	return formatFloat(*hours) + "h"
+}
+
+func formatFileSize(size int64) string {
+	units := []string{"B", "KB", "MB", "GB"}
+	value := float64(size)
+	unit := 0
+	for value >= 1024 && unit < len(units)-1 {
+		value /= 1024
+		unit++
+	}
+	return formatFloat(value) + " " + units[unit]
+}
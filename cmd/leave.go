@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jedib0t/go-pretty/table"
+	"github.com/mightymatth/arcli/client"
+	"github.com/mightymatth/arcli/config"
+	"github.com/spf13/cobra"
+)
+
+var (
+	leaveTo   string
+	leaveNote string
+)
+
+func newLeaveCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:     "leave",
+		Aliases: []string{"vacation"},
+		Short:   "Personal leave days, when no time is expected to be logged",
+	}
+
+	c.AddCommand(newLeaveListCmd())
+	c.AddCommand(newLeaveAddCmd())
+	c.AddCommand(newLeaveDeleteCmd())
+
+	return c
+}
+
+func newLeaveListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls", "all"},
+		Short:   "List of all leave days",
+		Run: func(cmd *cobra.Command, args []string) {
+			drawLeaveDays(config.GetLeaveDays())
+		},
+	}
+}
+
+func newLeaveAddCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:     "add [date...]",
+		Aliases: []string{"set", "new"},
+		Args:    cobra.MinimumNArgs(1),
+		Short:   "Add leave days",
+		Example: `arcli leave add 2026-10-20 --to 2026-10-24 -m vacation
+arcli leave add today -m sick`,
+		Run: func(cmd *cobra.Command, args []string) {
+			days, err := leaveDays(args, leaveTo)
+			if err != nil {
+				fmt.Println(err)
+				return
+			}
+
+			err = config.AddLeaveDays(days, leaveNote)
+			if err != nil {
+				fmt.Println(err)
+				return
+			}
+
+			fmt.Printf("%v leave days have been successfully added.\n", len(days))
+		},
+	}
+
+	c.Flags().StringVar(&leaveTo, "to", "",
+		"The last day of leave that starts on the given date")
+	c.Flags().StringVarP(&leaveNote, "message", "m", "",
+		"Short note (e.g. 'vacation')")
+
+	return c
+}
+
+func newLeaveDeleteCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:     "delete [date...]",
+		Aliases: []string{"remove", "rm", "del"},
+		Args:    cobra.MinimumNArgs(1),
+		Short:   "Remove leave days",
+		Run: func(cmd *cobra.Command, args []string) {
+			days, err := leaveDays(args, leaveTo)
+			if err != nil {
+				fmt.Println(err)
+				return
+			}
+
+			err = config.RemoveLeaveDays(days)
+			if err != nil {
+				fmt.Println(err)
+				return
+			}
+
+			fmt.Printf("%v leave days have been deleted.\n", len(days))
+		},
+	}
+
+	c.Flags().StringVar(&leaveTo, "to", "",
+		"The last day of leave that starts on the given date")
+
+	return c
+}
+
+// leaveDays returns dates given as arguments, with all days up to 'to' date if set.
+func leaveDays(args []string, to string) ([]string, error) {
+	if to != "" && len(args) != 1 {
+		return nil, fmt.Errorf("provide only the first day of leave with --to flag")
+	}
+
+	days := make([]string, 0, len(args))
+	for _, arg := range args {
+		day, err := spentOnModify(arg)
+		if err != nil {
+			return nil, err
+		}
+		days = append(days, day)
+	}
+
+	if to != "" {
+		last, err := spentOnParse(to)
+		if err != nil {
+			return nil, err
+		}
+
+		first, _ := time.Parse(client.DateTimeFormat, days[0])
+		if last.Before(first) {
+			return nil, fmt.Errorf("the last day of leave is before the first one")
+		}
+		for day := first.AddDate(0, 0, 1); !day.After(*last); day = day.AddDate(0, 0, 1) {
+			days = append(days, day.Format(client.DateTimeFormat))
+		}
+	}
+
+	return days, nil
+}
+
+func drawLeaveDays(leave map[string]string) {
+	if len(leave) == 0 && out.IsTable() {
+		fmt.Println("You have no leave days.")
+		fmt.Printf("These can be added with: '%v'\n", newLeaveAddCmd().UseLine())
+		return
+	}
+
+	rows := make([]table.Row, 0, len(leave))
+	for _, day := range sortedKeys(leave) {
+		date, _ := time.Parse(client.DateTimeFormat, day)
+		rows = append(rows, table.Row{date.Format(client.DayDateFormat), leave[day]})
+	}
+
+	render(leave, table.Row{"Date", "Note"}, rows)
+}
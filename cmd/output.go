@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/jedib0t/go-pretty/table"
+	"github.com/mightymatth/arcli/config"
+	"github.com/mightymatth/arcli/utils"
+)
+
+const (
+	// inlineTemplatePrefix precedes template given in output flag (e.g. '-o template={{.ID}}').
+	inlineTemplatePrefix = "template="
+	// namedTemplatePrefix precedes name of template saved in config (e.g. '-o tpl:branch').
+	namedTemplatePrefix = "tpl:"
+)
+
+var (
+	outputFlag   string
+	templateFlag string
+	out          = utils.NewOutput(utils.OutputTable)
+)
+
+func setupOutput() error {
+	switch {
+	case templateFlag != "":
+		return setupTemplateOutput(templateFlag)
+	case strings.HasPrefix(outputFlag, inlineTemplatePrefix):
+		return setupTemplateOutput(strings.TrimPrefix(outputFlag, inlineTemplatePrefix))
+	case strings.HasPrefix(outputFlag, namedTemplatePrefix):
+		name := strings.TrimPrefix(outputFlag, namedTemplatePrefix)
+		tmpl, found := config.GetTemplate(name)
+		if !found {
+			return fmt.Errorf("template '%v' does not exist (saved ones: [%v])",
+				name, utils.PrintWithDelimiter(sortedKeys(config.GetTemplates())))
+		}
+		return setupTemplateOutput(tmpl)
+	}
+
+	format, err := utils.ParseOutputFormat(outputFlag)
+	if err != nil {
+		return err
+	}
+
+	out = utils.NewOutput(format)
+
+	return nil
+}
+
+func setupTemplateOutput(tmpl string) error {
+	output, err := utils.NewTemplateOutput(tmpl, templateFuncs())
+	if err != nil {
+		return err
+	}
+
+	out = output
+
+	return nil
+}
+
+// templateFuncs returns template helper functions that depend on Redmine client.
+func templateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"issueURL":   RClient.IssueURL,
+		"projectURL": RClient.ProjectURL,
+	}
+}
+
+// renderPage prints page of results streamed in table format, with header only
+// above the first page.
+func renderPage(header table.Row, rows []table.Row, first bool) {
+	t := utils.NewTable()
+	if first {
+		t.AppendHeader(header)
+	}
+	t.AppendRows(rows)
+	t.Render()
+}
+
+// render prints data in output format selected by the user. Header and rows are
+// used for tabular formats, while data itself is encoded in structured ones.
+func render(data interface{}, header table.Row, rows []table.Row) {
+	err := out.Render(data, header, rows)
+	if err != nil {
+		fmt.Println("Cannot render output:", err)
+	}
+}
@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mightymatth/arcli/client"
+	"github.com/mightymatth/arcli/config"
+)
+
+// period represents date range with both days included.
+type period struct {
+	Name string
+	From time.Time
+	To   time.Time
+}
+
+var (
+	lastDaysRegex = regexp.MustCompile(`^last (\d+) (day|week)s?$`)
+	quarterRegex  = regexp.MustCompile(`^(\d{4})-?[Qq]([1-4])$`)
+	monthRegex    = regexp.MustCompile(`^(\d{4})-(\d{2})$`)
+	yearRegex     = regexp.MustCompile(`^(\d{4})$`)
+)
+
+// today returns the current date at midnight UTC, as dates are parsed by client.
+func today() time.Time {
+	now := time.Now()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// defaultPeriods returns periods shown by status when none is requested.
+func defaultPeriods(day time.Time) []period {
+	names := []string{"Today", "Yesterday", "This Week", "Last Week", "This Month", "Last Month"}
+
+	periods := make([]period, 0, len(names))
+	for _, name := range names {
+		p, _ := parsePeriod(name, day)
+		periods = append(periods, p)
+	}
+
+	return periods
+}
+
+// parsePeriod parses named period relative to given day ('today', 'yesterday',
+// 'this week', 'last month', 'this quarter', 'last year', 'last 14 days', 'last 2 weeks')
+// or calendar period ('2026-Q3', '2026-09', '2026-W41', '2026', '2026-09-15').
+func parsePeriod(name string, day time.Time) (period, error) {
+	normalized := strings.ToLower(strings.Join(strings.Fields(name), " "))
+	monday := day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	firstOfMonth := time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC)
+	firstOfQuarter := time.Date(day.Year(), (day.Month()-1)/3*3+1, 1, 0, 0, 0, 0, time.UTC)
+	firstOfYear := time.Date(day.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	p := period{Name: name}
+	switch normalized {
+	case "today":
+		p.From, p.To = day, day
+	case "yesterday":
+		p.From = day.AddDate(0, 0, -1)
+		p.To = p.From
+	case "this week":
+		p.From, p.To = monday, monday.AddDate(0, 0, 6)
+	case "last week":
+		p.From, p.To = monday.AddDate(0, 0, -7), monday.AddDate(0, 0, -1)
+	case "this month":
+		p.From, p.To = firstOfMonth, firstOfMonth.AddDate(0, 1, -1)
+	case "last month":
+		p.From, p.To = firstOfMonth.AddDate(0, -1, 0), firstOfMonth.AddDate(0, 0, -1)
+	case "this quarter":
+		p.From, p.To = firstOfQuarter, firstOfQuarter.AddDate(0, 3, -1)
+	case "last quarter":
+		p.From, p.To = firstOfQuarter.AddDate(0, -3, 0), firstOfQuarter.AddDate(0, 0, -1)
+	case "this year":
+		p.From, p.To = firstOfYear, firstOfYear.AddDate(1, 0, -1)
+	case "last year":
+		p.From, p.To = firstOfYear.AddDate(-1, 0, 0), firstOfYear.AddDate(0, 0, -1)
+	default:
+		return parseCalendarPeriod(p, normalized, day)
+	}
+
+	return p, nil
+}
+
+func parseCalendarPeriod(p period, name string, day time.Time) (period, error) {
+	if match := lastDaysRegex.FindStringSubmatch(name); match != nil {
+		n, _ := strconv.Atoi(match[1])
+		if match[2] == "week" {
+			n *= 7
+		}
+		if n < 1 {
+			return p, fmt.Errorf("period '%v' has no days", p.Name)
+		}
+		p.From, p.To = day.AddDate(0, 0, 1-n), day
+		return p, nil
+	}
+
+	if match := quarterRegex.FindStringSubmatch(name); match != nil {
+		year, _ := strconv.Atoi(match[1])
+		quarter, _ := strconv.Atoi(match[2])
+		p.From = time.Date(year, time.Month(quarter-1)*3+1, 1, 0, 0, 0, 0, time.UTC)
+		p.To = p.From.AddDate(0, 3, -1)
+		return p, nil
+	}
+
+	if match := monthRegex.FindStringSubmatch(name); match != nil {
+		year, _ := strconv.Atoi(match[1])
+		month, _ := strconv.Atoi(match[2])
+		if month < 1 || month > 12 {
+			return p, fmt.Errorf("invalid month in period '%v'", p.Name)
+		}
+		p.From = time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+		p.To = p.From.AddDate(0, 1, -1)
+		return p, nil
+	}
+
+	if match := yearRegex.FindStringSubmatch(name); match != nil {
+		year, _ := strconv.Atoi(match[1])
+		p.From = time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+		p.To = p.From.AddDate(1, 0, -1)
+		return p, nil
+	}
+
+	if isoWeekRegex.MatchString(name) {
+		monday, err := parseISOWeek(name, day)
+		if err != nil {
+			return p, err
+		}
+		p.From, p.To = monday, monday.AddDate(0, 0, 6)
+		return p, nil
+	}
+
+	if date, err := time.Parse(client.DateTimeFormat, name); err == nil {
+		p.From, p.To = date, date
+		return p, nil
+	}
+
+	return p, fmt.Errorf("invalid period '%v' (use e.g. 'this week', 'last 14 days', "+
+		"'2026-Q3', '2026-09', '2026-W41' or '2026')", p.Name)
+}
+
+// workTargets represents hours expected to be logged on every weekday.
+type workTargets struct {
+	hours [7]float64
+	set   bool
+}
+
+// loadWorkTargets reads targets from defaults. Weekday targets override daily
+// target, which overrides weekly target split over working days.
+func loadWorkTargets() (workTargets, error) {
+	defaults := config.Defaults()
+	var targets workTargets
+
+	parse := func(key config.DefaultsKey) (float64, bool, error) {
+		value, found := defaults[string(key)]
+		if !found || value == "" {
+			return 0, false, nil
+		}
+
+		hours, err := parseTimesheetHours(value)
+		if err != nil {
+			return 0, false, fmt.Errorf("invalid %v default: %v", key, err)
+		}
+
+		targets.set = true
+		return hours, true, nil
+	}
+
+	weekly, weeklySet, err := parse(config.WeeklyTarget)
+	if err != nil {
+		return targets, err
+	}
+	daily, dailySet, err := parse(config.DailyTarget)
+	if err != nil {
+		return targets, err
+	}
+	if !dailySet && weeklySet {
+		daily = weekly / 5
+	}
+
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if day != time.Saturday && day != time.Sunday {
+			targets.hours[day] = daily
+		}
+
+		hours, found, err := parse(config.WeekdayTarget(day))
+		if err != nil {
+			return targets, err
+		}
+		if found {
+			targets.hours[day] = hours
+		}
+	}
+
+	return targets, nil
+}
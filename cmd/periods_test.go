@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mightymatth/arcli/client"
+)
+
+func utcDay(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// sunday is the day periods are relative to, unless set otherwise.
+var sunday = utcDay(2026, time.October, 18)
+
+func TestParsePeriod(t *testing.T) {
+	newYear := utcDay(2027, time.January, 1) // Friday
+
+	tests := []struct {
+		name     string
+		day      time.Time
+		from, to time.Time
+	}{
+		{"today", sunday, sunday, sunday},
+		{"Yesterday", sunday, utcDay(2026, time.October, 17), utcDay(2026, time.October, 17)},
+		{"this week", sunday, utcDay(2026, time.October, 12), sunday},
+		{"this week", utcDay(2026, time.October, 12), utcDay(2026, time.October, 12), sunday},
+		{"Last  Week", sunday, utcDay(2026, time.October, 5), utcDay(2026, time.October, 11)},
+		{"this month", sunday, utcDay(2026, time.October, 1), utcDay(2026, time.October, 31)},
+		{"last month", sunday, utcDay(2026, time.September, 1), utcDay(2026, time.September, 30)},
+		{"this quarter", sunday, utcDay(2026, time.October, 1), utcDay(2026, time.December, 31)},
+		{"last quarter", sunday, utcDay(2026, time.July, 1), utcDay(2026, time.September, 30)},
+		{"this year", sunday, utcDay(2026, time.January, 1), utcDay(2026, time.December, 31)},
+		{"last year", sunday, utcDay(2025, time.January, 1), utcDay(2025, time.December, 31)},
+		{"last 1 day", sunday, sunday, sunday},
+		{"last 14 days", sunday, utcDay(2026, time.October, 5), sunday},
+		{"last 2 weeks", sunday, utcDay(2026, time.October, 5), sunday},
+		{"2026-Q3", sunday, utcDay(2026, time.July, 1), utcDay(2026, time.September, 30)},
+		{"2026q1", sunday, utcDay(2026, time.January, 1), utcDay(2026, time.March, 31)},
+		{"2024-02", sunday, utcDay(2024, time.February, 1), utcDay(2024, time.February, 29)},
+		{"2025", sunday, utcDay(2025, time.January, 1), utcDay(2025, time.December, 31)},
+		{"2026-W42", sunday, utcDay(2026, time.October, 12), sunday},
+		{"2026w1", sunday, utcDay(2025, time.December, 29), utcDay(2026, time.January, 4)},
+		{"2026-09-15", sunday, utcDay(2026, time.September, 15), utcDay(2026, time.September, 15)},
+
+		// year boundary
+		{"this week", newYear, utcDay(2026, time.December, 28), utcDay(2027, time.January, 3)},
+		{"last week", newYear, utcDay(2026, time.December, 21), utcDay(2026, time.December, 27)},
+		{"last month", newYear, utcDay(2026, time.December, 1), utcDay(2026, time.December, 31)},
+		{"last quarter", newYear, utcDay(2026, time.October, 1), utcDay(2026, time.December, 31)},
+		{"last 7 days", newYear, utcDay(2026, time.December, 26), newYear},
+		{"2026-W53", sunday, utcDay(2026, time.December, 28), utcDay(2027, time.January, 3)},
+		{"2025-W01", sunday, utcDay(2024, time.December, 30), utcDay(2025, time.January, 5)},
+	}
+
+	for _, tt := range tests {
+		got, err := parsePeriod(tt.name, tt.day)
+		if err != nil {
+			t.Errorf("parsePeriod(%q, %v) returned error: %v", tt.name, tt.day.Format(client.DateTimeFormat), err)
+			continue
+		}
+		if got.Name != tt.name || !got.From.Equal(tt.from) || !got.To.Equal(tt.to) {
+			t.Errorf("parsePeriod(%q, %v) = %v %v..%v, want %v..%v", tt.name, tt.day.Format(client.DateTimeFormat),
+				got.Name, got.From.Format(client.DateTimeFormat), got.To.Format(client.DateTimeFormat),
+				tt.from.Format(client.DateTimeFormat), tt.to.Format(client.DateTimeFormat))
+		}
+	}
+}
+
+func TestParsePeriodInvalid(t *testing.T) {
+	for _, name := range []string{"", "someday", "next week", "last 0 days", "2026-Q5", "2026-13", "2025-W53",
+		"2026-W00", "2026-W54", "2026-02-30"} {
+		if got, err := parsePeriod(name, sunday); err == nil {
+			t.Errorf("parsePeriod(%q) = %v..%v, want error", name, got.From.Format(client.DateTimeFormat),
+				got.To.Format(client.DateTimeFormat))
+		}
+	}
+}
+
+func TestParseISOWeek(t *testing.T) {
+	now := time.Date(2027, time.January, 1, 23, 30, 0, 0, time.Local) // Friday
+
+	tests := []struct {
+		week string
+		want time.Time
+	}{
+		{"", utcDay(2026, time.December, 28)},
+		{"this", utcDay(2026, time.December, 28)},
+		{"Last", utcDay(2026, time.December, 21)},
+		{"2026-W42", utcDay(2026, time.October, 12)},
+		{"2026-w42", utcDay(2026, time.October, 12)},
+		{"2026W42", utcDay(2026, time.October, 12)},
+
+		// weeks 53 and first weeks starting in previous year
+		{"2026-W53", utcDay(2026, time.December, 28)},
+		{"2020-W53", utcDay(2020, time.December, 28)},
+		{"2015-W53", utcDay(2015, time.December, 28)},
+		{"2025-W01", utcDay(2024, time.December, 30)},
+		{"2026-W1", utcDay(2025, time.December, 29)},
+		{"2024-W01", utcDay(2024, time.January, 1)},
+		{"2021-W01", utcDay(2021, time.January, 4)},
+		{"2027-W01", utcDay(2027, time.January, 4)},
+		{"2025-W52", utcDay(2025, time.December, 22)},
+	}
+
+	for _, tt := range tests {
+		got, err := parseISOWeek(tt.week, now)
+		if err != nil {
+			t.Errorf("parseISOWeek(%q) returned error: %v", tt.week, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("parseISOWeek(%q) = %v, want %v", tt.week, got.Format(client.DateTimeFormat),
+				tt.want.Format(client.DateTimeFormat))
+		}
+	}
+}
+
+func TestParseISOWeekInvalid(t *testing.T) {
+	for _, week := range []string{"next", "W42", "2026-42", "2026-W0", "2026-W54", "2025-W53", "2021-W53",
+		"2026-W100"} {
+		if got, err := parseISOWeek(week, sunday); err == nil {
+			t.Errorf("parseISOWeek(%q) = %v, want error", week, got.Format(client.DateTimeFormat))
+		}
+	}
+}
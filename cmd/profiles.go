@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+
+	"github.com/jedib0t/go-pretty/table"
+	"github.com/mightymatth/arcli/config"
+	"github.com/spf13/cobra"
+)
+
+func newProfilesCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:     "profile",
+		Aliases: []string{"profiles", "pr"},
+		Short:   "Named Redmine server profiles",
+		Long: `Named Redmine server profiles. Each profile has its own credentials, aliases and defaults.
+Active profile can be selected with 'profile use', '--profile' flag or ARCLI_PROFILE environment variable.`,
+	}
+
+	c.AddCommand(newProfilesListCmd())
+	c.AddCommand(newProfilesAddCmd())
+	c.AddCommand(newProfilesUseCmd())
+	c.AddCommand(newProfilesDeleteCmd())
+
+	return c
+}
+
+func newProfilesListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls", "all"},
+		Short:   "List of all profiles",
+		Run: func(cmd *cobra.Command, args []string) {
+			drawProfiles()
+		},
+	}
+}
+
+var (
+	profileServer, profileCaCert string
+)
+
+func newProfilesAddCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:     "add [profileName]",
+		Aliases: []string{"new", "create"},
+		Args:    validProfileNameArgs(),
+		Short:   "Add profile (log in to it with 'arcli login --profile [profileName]')",
+		Run: func(cmd *cobra.Command, args []string) {
+			if profileCaCert != "" {
+				certAbsPath, err := filepath.Abs(profileCaCert)
+				if err != nil {
+					fmt.Println("Cannot fetch certificate absolute path:", err)
+					return
+				}
+				profileCaCert = certAbsPath
+			}
+
+			err := config.AddProfile(args[0], profileServer, profileCaCert)
+			if err != nil {
+				fmt.Println(err)
+				return
+			}
+
+			fmt.Printf("Profile '%v' has been added.\n", args[0])
+		},
+	}
+
+	c.Flags().StringVarP(&profileServer, "server", "s", "", "Host of Redmine server (e.g. https://host.redmine.org)")
+	c.Flags().StringVarP(&profileCaCert, "cacert", "c", "", "CA Certificate")
+
+	return c
+}
+
+func newProfilesUseCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "use [profileName]",
+		Aliases: []string{"switch", "select"},
+		Args:    cobra.ExactArgs(1),
+		Short:   "Set active profile",
+		Run: func(cmd *cobra.Command, args []string) {
+			err := config.UseProfile(args[0])
+			if err != nil {
+				fmt.Println(err)
+				return
+			}
+
+			fmt.Printf("Profile '%v' is now active.\n", args[0])
+		},
+	}
+}
+
+func newProfilesDeleteCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "delete [profileName]",
+		Aliases: []string{"remove", "rm", "del"},
+		Args:    cobra.ExactArgs(1),
+		Short:   "Remove profile with its credentials, aliases and defaults",
+		Run: func(cmd *cobra.Command, args []string) {
+			err := config.RemoveProfile(args[0])
+			if err != nil {
+				fmt.Println("Cannot delete profile:", err)
+				return
+			}
+
+			fmt.Printf("Profile '%v' has been deleted.\n", args[0])
+		},
+	}
+}
+
+func drawProfiles() {
+	profiles := config.Profiles()
+	if len(profiles) == 0 && out.IsTable() {
+		fmt.Println("You have no profiles yet.")
+		fmt.Printf("These can be added with: '%v'\n", newProfilesAddCmd().UseLine())
+		return
+	}
+
+	infos := make([]profileInfo, 0, len(profiles))
+	rows := make([]table.Row, 0, len(profiles))
+	for _, name := range profiles {
+		info := profileInfo{
+			Name:   name,
+			Host:   config.ProfileValue(name, config.Host),
+			UserID: config.ProfileValue(name, config.UserID),
+			Active: name == config.ActiveProfile(),
+		}
+		infos = append(infos, info)
+
+		var active string
+		if info.Active {
+			active = "*"
+		}
+		rows = append(rows, table.Row{active, info.Name, info.Host, info.UserID})
+	}
+
+	render(infos, table.Row{"", "Profile", "Host", "User ID"}, rows)
+}
+
+// profileInfo represents profile in structured output formats.
+type profileInfo struct {
+	Name   string `json:"name"`
+	Host   string `json:"host"`
+	UserID string `json:"user_id"`
+	Active bool   `json:"active"`
+}
+
+func validProfileNameArgs() cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		err := cobra.ExactArgs(1)(cmd, args)
+		if err != nil {
+			return err
+		}
+
+		namePattern := "^[a-z0-9-_]{1,30}$"
+		if !regexp.MustCompile(namePattern).MatchString(args[0]) {
+			return fmt.Errorf("profile name must have pattern '%v'", namePattern)
+		}
+
+		return nil
+	}
+}
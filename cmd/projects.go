@@ -6,6 +6,7 @@ import (
 
 	"github.com/mightymatth/arcli/config"
 
+	"github.com/jedib0t/go-pretty/table"
 	"github.com/jedib0t/go-pretty/text"
 
 	"github.com/mightymatth/arcli/client"
@@ -48,16 +49,40 @@ func validProjectArgs() cobra.PositionalArgs {
 	}
 }
 
+// resolveProjectID returns ID of project given as ID, alias or identifier.
+func resolveProjectID(project string) (int64, error) {
+	if val, found := config.GetAlias(project); found {
+		project = val
+	}
+
+	id, err := strconv.ParseInt(project, 10, 64)
+	if err == nil {
+		return id, nil
+	}
+
+	found, err := RClient.GetProjectByIdentifier(ctx, project)
+	if err != nil {
+		return 0, err
+	}
+
+	return found.ID, nil
+}
+
 func projectFunc(_ *cobra.Command, args []string) {
 	projectID, _ := strconv.ParseInt(args[0], 10, 64)
-	project, err := RClient.GetProject(projectID)
+	project, err := RClient.GetProject(ctx, projectID)
 	if err != nil {
 		fmt.Printf("Cannot fetch project with id %v\n", projectID)
 		return
 	}
 
+	if !out.IsTable() {
+		drawProjects([]client.Project{*project})
+		return
+	}
+
 	fmt.Printf("[%v] %v\n", text.FgYellow.Sprint(project.ID), text.FgYellow.Sprint(project.Identifier))
-	fmt.Printf("%v (%v)\n", text.FgGreen.Sprint(project.Name), project.URL())
+	fmt.Printf("%v (%v)\n", text.FgGreen.Sprint(project.Name), RClient.ProjectURL(project.ID))
 	fmt.Printf("%v\n", project.Description)
 }
 
@@ -67,13 +92,25 @@ func newMyProjectsCmd() *cobra.Command {
 		Aliases: []string{"all", "show", "ls", "list"},
 		Short:   "List all projects visible to user",
 		Run: func(cmd *cobra.Command, args []string) {
-			projects, err := RClient.GetProjects()
+			if !out.IsTable() {
+				projects, err := RClient.GetProjects(ctx)
+				if err != nil {
+					fmt.Println("Cannot fetch projects:", err)
+					return
+				}
+
+				drawProjects(projects)
+				return
+			}
+
+			err := RClient.StreamProjects(ctx, func(projects []client.Project) error {
+				drawProjects(projects)
+				return nil
+			})
 			if err != nil {
 				fmt.Println("Cannot fetch projects:", err)
 				return
 			}
-
-			drawProjects(projects)
 		},
 	}
 
@@ -81,6 +118,20 @@ func newMyProjectsCmd() *cobra.Command {
 }
 
 func drawProjects(projects []client.Project) {
+	if !out.IsTable() {
+		rows := make([]table.Row, 0, len(projects))
+		for _, project := range projects {
+			var parentID int64
+			if project.Parent != nil {
+				parentID = project.Parent.ID
+			}
+			rows = append(rows, table.Row{project.ID, project.Identifier, project.Name, parentID, RClient.ProjectURL(project.ID)})
+		}
+
+		render(projects, table.Row{"ID", "Identifier", "Name", "Parent ID", "URL"}, rows)
+		return
+	}
+
 	for _, project := range projects {
 		if project.Parent == nil {
 			fmt.Printf("[%v] %v\n", text.FgYellow.Sprint(project.ID),
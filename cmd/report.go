@@ -0,0 +1,386 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jedib0t/go-pretty/table"
+	"github.com/mightymatth/arcli/client"
+	"github.com/spf13/cobra"
+)
+
+var (
+	reportFrom    string
+	reportTo      string
+	reportUser    string
+	reportProject string
+	reportIssue   string
+	reportGroupBy []string
+	reportPivot   string
+)
+
+// reportCriterion groups time entries by key. Groups are sorted by key and
+// printed with label.
+type reportCriterion struct {
+	name  string
+	group func(entry client.TimeEntry) (key, label string)
+	// dated criteria depend only on the date the time was spent on
+	dated bool
+}
+
+var reportCriteria = []reportCriterion{
+	{"project", func(e client.TimeEntry) (string, string) {
+		return fmt.Sprintf("%v\x00%v", e.Project.Name, e.Project.ID), e.Project.Name
+	}, false},
+	{"issue", func(e client.TimeEntry) (string, string) {
+		if e.Issue.ID == 0 {
+			return "", "(no issue)"
+		}
+		return fmt.Sprintf("%010d", e.Issue.ID), fmt.Sprintf("#%v", e.Issue.ID)
+	}, false},
+	{"activity", func(e client.TimeEntry) (string, string) {
+		return e.Activity.Name, e.Activity.Name
+	}, false},
+	{"user", func(e client.TimeEntry) (string, string) {
+		return e.User.Name, e.User.Name
+	}, false},
+	{"day", func(e client.TimeEntry) (string, string) {
+		day := e.SpentOn.Format(client.DateTimeFormat)
+		return day, day
+	}, true},
+	{"week", func(e client.TimeEntry) (string, string) {
+		year, week := e.SpentOn.ISOWeek()
+		label := fmt.Sprintf("%d-W%02d", year, week)
+		return label, label
+	}, true},
+	{"month", func(e client.TimeEntry) (string, string) {
+		return e.SpentOn.Format("2006-01"), e.SpentOn.Format("2006-01")
+	}, true},
+}
+
+// timeReport represents time entries grouped by criteria.
+type timeReport struct {
+	From    client.DateTime `json:"from"`
+	To      client.DateTime `json:"to"`
+	GroupBy []string        `json:"group_by"`
+	Pivot   string          `json:"pivot,omitempty"`
+	Columns []string        `json:"columns,omitempty"`
+	Hours   float64         `json:"hours"`
+	Groups  []*reportGroup  `json:"groups"`
+}
+
+// reportGroup represents time entries with the same value of criterion, split
+// by the next criterion into subgroups.
+type reportGroup struct {
+	Criterion string             `json:"criterion"`
+	Name      string             `json:"name"`
+	Hours     float64            `json:"hours"`
+	Percent   float64            `json:"percent"`
+	Pivot     map[string]float64 `json:"pivot,omitempty"`
+	Groups    []*reportGroup     `json:"groups,omitempty"`
+
+	key     string
+	byKey   map[string]*reportGroup
+	columns map[string]float64
+}
+
+func newReportCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:     "report",
+		Aliases: []string{"r"},
+		Short:   "Spent time report grouped by criteria",
+		Long: fmt.Sprintf(`Aggregate time entries of date range into nested groups with subtotals and
+percentages of total time, like 'Spent time' report in Redmine. With --pivot flag,
+hours are additionally split into columns.
+
+Available criteria: %v`, strings.Join(reportCriterionNames(), ", ")),
+		Example: `arcli report --from 2026-09-01 --to 2026-09-30 --group-by project,activity
+arcli report --group-by issue --pivot day -o csv`,
+		Args: cobra.NoArgs,
+		Run:  reportFunc,
+	}
+
+	c.Flags().StringVar(&reportFrom, "from", "",
+		"The first day of range ('today', 'yesterday', '2020-01-15'; default first day of this month)")
+	c.Flags().StringVar(&reportTo, "to", "today",
+		"The last day of range ('today', 'yesterday', '2020-01-15')")
+	c.Flags().StringVarP(&reportUser, "user", "u", "me",
+		"User whose time entries are reported ('me', 'all', id or login)")
+	c.Flags().StringVarP(&reportProject, "project", "p", "",
+		"Report only time entries of project (id, identifier or alias)")
+	c.Flags().StringVarP(&reportIssue, "issue", "i", "",
+		"Report only time entries of issue (id or alias)")
+	c.Flags().StringSliceVarP(&reportGroupBy, "group-by", "g", []string{"project"},
+		"Criteria of nested groups, in order")
+	c.Flags().StringVar(&reportPivot, "pivot", "",
+		"Criterion of columns (e.g. 'day')")
+
+	return c
+}
+
+func reportFunc(_ *cobra.Command, _ []string) {
+	if len(reportGroupBy) == 0 {
+		fmt.Println("Provide at least one criterion to group by")
+		return
+	}
+
+	criteria := make([]reportCriterion, 0, len(reportGroupBy))
+	for _, name := range reportGroupBy {
+		criterion, err := findReportCriterion(name)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		criteria = append(criteria, criterion)
+	}
+
+	var pivot *reportCriterion
+	if reportPivot != "" {
+		criterion, err := findReportCriterion(reportPivot)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		if contains(reportGroupBy, criterion.name) {
+			fmt.Printf("Criterion '%v' cannot be used both for groups and pivot\n", criterion.name)
+			return
+		}
+		pivot = &criterion
+	}
+
+	query, err := timeEntriesRangeQuery(reportFrom, reportTo, reportUser, reportProject, reportIssue)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	entries, err := RClient.GetTimeEntries(ctx, query.Encode())
+	if err != nil {
+		fmt.Println("Cannot get time entries:", err)
+		return
+	}
+
+	from, _ := time.Parse(client.DateTimeFormat, query.Get("from"))
+	to, _ := time.Parse(client.DateTimeFormat, query.Get("to"))
+
+	report := buildTimeReport(entries, criteria, pivot, from, to)
+	if contains(report.GroupBy, "issue") {
+		ids := make([]int64, 0, len(entries))
+		for _, entry := range entries {
+			if entry.Issue.ID != 0 {
+				ids = append(ids, entry.Issue.ID)
+			}
+		}
+		fillReportSubjects(report.Groups, issueSubjects(ids))
+	}
+
+	drawTimeReport(report)
+}
+
+// fillReportSubjects adds issue subjects to names of issue groups.
+func fillReportSubjects(groups []*reportGroup, subjects map[int64]string) {
+	for _, group := range groups {
+		if group.Criterion == "issue" && group.key != "" {
+			id, _ := strconv.ParseInt(group.key, 10, 64)
+			if subject := subjects[id]; subject != "" {
+				group.Name = fmt.Sprintf("#%v %v", id, subject)
+			}
+		}
+		fillReportSubjects(group.Groups, subjects)
+	}
+}
+
+func reportCriterionNames() []string {
+	names := make([]string, 0, len(reportCriteria))
+	for _, criterion := range reportCriteria {
+		names = append(names, criterion.name)
+	}
+	return names
+}
+
+func findReportCriterion(name string) (reportCriterion, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for _, criterion := range reportCriteria {
+		if criterion.name == name {
+			return criterion, nil
+		}
+	}
+
+	return reportCriterion{}, fmt.Errorf("invalid criterion '%v' (allowed ones: [%v])",
+		name, strings.Join(reportCriterionNames(), ", "))
+}
+
+func buildTimeReport(entries []client.TimeEntry, criteria []reportCriterion, pivot *reportCriterion,
+	from, to time.Time) *timeReport {
+	report := &timeReport{
+		From:   *client.NewDateTime(from),
+		To:     *client.NewDateTime(to),
+		Groups: []*reportGroup{},
+	}
+	for _, criterion := range criteria {
+		report.GroupBy = append(report.GroupBy, criterion.name)
+	}
+
+	// dated pivot columns cover the whole range, so days without time are shown as well
+	columnLabels := make(map[string]string)
+	if pivot != nil {
+		report.Pivot = pivot.name
+	}
+	if pivot != nil && pivot.dated {
+		for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+			key, label := pivot.group(client.TimeEntry{SpentOn: *client.NewDateTime(day)})
+			columnLabels[key] = label
+		}
+	}
+
+	root := &reportGroup{}
+	for _, entry := range entries {
+		var column string
+		if pivot != nil {
+			key, label := pivot.group(entry)
+			columnLabels[key] = label
+			column = key
+		}
+
+		report.Hours += entry.Hours
+		group := root
+		for _, criterion := range criteria {
+			group = group.subgroup(criterion, entry)
+			group.Hours += entry.Hours
+			if pivot != nil {
+				group.columns[column] += entry.Hours
+			}
+		}
+	}
+
+	var columnKeys []string
+	for key := range columnLabels {
+		columnKeys = append(columnKeys, key)
+	}
+	sort.Strings(columnKeys)
+	for _, key := range columnKeys {
+		report.Columns = append(report.Columns, columnLabels[key])
+	}
+
+	root.finish(report.Hours, columnKeys, columnLabels)
+	if root.Groups != nil {
+		report.Groups = root.Groups
+	}
+
+	return report
+}
+
+// subgroup returns group of entry by criterion, creating it if needed.
+func (g *reportGroup) subgroup(criterion reportCriterion, entry client.TimeEntry) *reportGroup {
+	key, label := criterion.group(entry)
+	if g.byKey == nil {
+		g.byKey = make(map[string]*reportGroup)
+	}
+
+	sub, found := g.byKey[key]
+	if !found {
+		sub = &reportGroup{Criterion: criterion.name, Name: label, key: key,
+			columns: make(map[string]float64)}
+		g.byKey[key] = sub
+		g.Groups = append(g.Groups, sub)
+	}
+
+	return sub
+}
+
+// finish sorts subgroups and calculates percentages and pivot values.
+func (g *reportGroup) finish(total float64, columnKeys []string, columnLabels map[string]string) {
+	sort.Slice(g.Groups, func(i, j int) bool { return g.Groups[i].key < g.Groups[j].key })
+
+	for _, sub := range g.Groups {
+		if total != 0 {
+			sub.Percent = sub.Hours / total * 100
+		}
+		if len(columnKeys) != 0 {
+			sub.Pivot = make(map[string]float64, len(columnKeys))
+			for _, key := range columnKeys {
+				sub.Pivot[columnLabels[key]] = sub.columns[key]
+			}
+		}
+		sub.finish(total, columnKeys, columnLabels)
+	}
+}
+
+func drawTimeReport(report *timeReport) {
+	header := table.Row{}
+	for _, name := range report.GroupBy {
+		header = append(header, strings.ToUpper(name[:1])+name[1:])
+	}
+	for _, column := range report.Columns {
+		header = append(header, report.displayName(report.Pivot, column))
+	}
+	header = append(header, "Hours", "%")
+
+	var rows []table.Row
+	var addRows func(groups []*reportGroup, depth int)
+	addRows = func(groups []*reportGroup, depth int) {
+		for _, group := range groups {
+			row := make(table.Row, len(report.GroupBy))
+			for i := range row {
+				row[i] = ""
+			}
+			row[depth] = report.displayName(group.Criterion, group.Name)
+
+			for _, column := range report.Columns {
+				row = append(row, formatTimesheetHours(group.Pivot[column]))
+			}
+			row = append(row, formatTimesheetHours(group.Hours), fmt.Sprintf("%.1f", group.Percent))
+			rows = append(rows, row)
+
+			addRows(group.Groups, depth+1)
+		}
+	}
+	addRows(report.Groups, 0)
+
+	totals := make(table.Row, len(report.GroupBy))
+	for i := range totals {
+		totals[i] = ""
+	}
+	totals[0] = "Total"
+	for _, column := range report.Columns {
+		var hours float64
+		for _, group := range report.Groups {
+			hours += group.Pivot[column]
+		}
+		totals = append(totals, formatTimesheetHours(hours))
+	}
+	percent := "100.0"
+	if report.Hours == 0 {
+		percent = "0.0"
+	}
+	totals = append(totals, formatTimesheetHours(report.Hours), percent)
+	rows = append(rows, totals)
+
+	if out.IsTable() {
+		fmt.Printf("Spent time from %v to %v\n", report.From.Format(client.DayDateFormat),
+			report.To.Format(client.DayDateFormat))
+	}
+
+	render(report, header, rows)
+}
+
+// displayName returns name of group or pivot column by criterion in table. Days
+// are shown with weekday, and without year if the whole report is in the same year.
+func (r *timeReport) displayName(criterion, name string) string {
+	if criterion != "day" {
+		return name
+	}
+
+	day, err := time.Parse(client.DateTimeFormat, name)
+	if err != nil {
+		return name
+	}
+
+	if r.From.Year() != r.To.Year() {
+		return day.Format("Mon 2006-01-02")
+	}
+	return day.Format("Mon 01-02")
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mightymatth/arcli/client"
+)
+
+func TestBuildTimeReportDayPivot(t *testing.T) {
+	entry := func(year int, hours float64) client.TimeEntry {
+		e := client.TimeEntry{Hours: hours, SpentOn: *client.NewDateTime(utcDay(year, time.October, 18))}
+		e.Project.ID, e.Project.Name = 1, "Webshop"
+		return e
+	}
+
+	project, _ := findReportCriterion("project")
+	day, _ := findReportCriterion("day")
+	from, to := utcDay(2025, time.October, 1), utcDay(2026, time.October, 31)
+
+	report := buildTimeReport([]client.TimeEntry{entry(2025, 2), entry(2026, 3), entry(2026, 1)},
+		[]reportCriterion{project}, &day, from, to)
+
+	if want := int(to.Sub(from).Hours()/24) + 1; len(report.Columns) != want {
+		t.Errorf("report has %v columns, want %v", len(report.Columns), want)
+	}
+	if len(report.Groups) != 1 {
+		t.Fatalf("report has %v groups, want 1", len(report.Groups))
+	}
+
+	pivot := report.Groups[0].Pivot
+	for column, want := range map[string]float64{"2025-10-18": 2, "2026-10-18": 4, "2026-10-19": 0} {
+		if got, found := pivot[column]; !found || got != want {
+			t.Errorf("pivot[%v] = %v, want %v", column, got, want)
+		}
+	}
+}
+
+func TestTimeReportDisplayName(t *testing.T) {
+	sameYear := &timeReport{From: *client.NewDateTime(utcDay(2026, time.October, 1)),
+		To: *client.NewDateTime(utcDay(2026, time.October, 31))}
+	years := &timeReport{From: *client.NewDateTime(utcDay(2025, time.October, 1)),
+		To: *client.NewDateTime(utcDay(2026, time.October, 31))}
+
+	tests := []struct {
+		report    *timeReport
+		criterion string
+		name      string
+		want      string
+	}{
+		{sameYear, "day", "2026-10-18", "Sun 10-18"},
+		{years, "day", "2025-10-18", "Sat 2025-10-18"},
+		{years, "day", "2026-10-18", "Sun 2026-10-18"},
+		{sameYear, "week", "2026-W42", "2026-W42"},
+		{sameYear, "project", "2026-10-18", "2026-10-18"},
+		{sameYear, "", "2026-10", "2026-10"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.report.displayName(tt.criterion, tt.name); got != tt.want {
+			t.Errorf("displayName(%q, %q) = %v, want %v", tt.criterion, tt.name, got, tt.want)
+		}
+	}
+}
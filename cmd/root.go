@@ -1,13 +1,21 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/mightymatth/arcli/client"
 	"github.com/mightymatth/arcli/config"
+	"github.com/mightymatth/arcli/utils"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
 type version struct {
@@ -22,12 +30,23 @@ var (
 	// VERSION holds version tool version information.
 	VERSION     version
 	versionFlag bool
+	profileFlag string
+	timeoutFlag time.Duration
+
+	debugFlag, debugBodyFlag bool
+	traceFileFlag            string
 )
 
+// defaultTimeout is the default time limit of a single request to Redmine server.
+const defaultTimeout = 30 * time.Second
+
 var rootCmd = &cobra.Command{
 	Use:   "arcli",
 	Short: "Awesome Redmine CLI",
 	Long:  `Awesome Redmine CLI. Wrapper around Redmine API`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return setupOutput()
+	},
 	Run: func(cmd *cobra.Command, args []string) {
 		if versionFlag {
 			fmt.Println(VERSION)
@@ -40,6 +59,99 @@ var rootCmd = &cobra.Command{
 // RClient is shareable Redmine client.
 var RClient *client.Client
 
+// harRecorder records requests to Redmine server written to trace file, if set.
+var harRecorder *client.HARRecorder
+
+// ctx is context of requests to Redmine server, which is cancelled on interrupt.
+var ctx = context.Background()
+
+// setupClient creates Redmine client with credentials of the active profile.
+// If the client cannot be created (e.g. CA certificate is not readable), its
+// requests fail with the reason, so that commands not making requests (e.g.
+// login) can still fix the profile.
+func setupClient() {
+	host, apiKey := viper.GetString(config.Key(config.Host)), viper.GetString(config.Key(config.APIKey))
+	c, err := newClient(host, viper.GetString(config.Key(config.CaCert)), client.WithAPIKey(apiKey))
+	if err != nil {
+		err = fmt.Errorf("cannot create Redmine client: %w", err)
+		c = &client.Client{BaseURL: host, APIKey: apiKey, HTTPClient: &http.Client{Transport: failingTransport{err}}}
+	}
+
+	RClient = c
+}
+
+// failingTransport fails every request with given error.
+type failingTransport struct {
+	err error
+}
+
+func (t failingTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, t.err
+}
+
+// newClient creates Redmine client for server on given host, which trusts
+// given CA certificate (if not empty).
+func newClient(host, caCert string, opts ...client.Option) (*client.Client, error) {
+	clientOpts := []client.Option{
+		client.WithBaseURL(host),
+		client.WithCACert(caCert),
+		client.WithUserAgent(fmt.Sprintf("arcli/v%s", VERSION.Version)),
+		client.WithTimeout(timeoutFlag),
+	}
+	if debugFlag || debugBodyFlag {
+		clientOpts = append(clientOpts, client.WithDebug(os.Stderr, debugBodyFlag))
+	}
+	if traceFileFlag != "" {
+		if harRecorder == nil {
+			harRecorder = &client.HARRecorder{Creator: "arcli", CreatorVersion: VERSION.Version}
+		}
+		clientOpts = append(clientOpts, client.WithHARRecorder(harRecorder))
+	}
+
+	// every attempt of retried request is debugged and traced
+	var logRetry func(format string, args ...interface{})
+	if debugFlag || debugBodyFlag {
+		logRetry = func(format string, args ...interface{}) {
+			fmt.Fprintf(os.Stderr, "Request failed: "+format+"\n", args...)
+		}
+	}
+	clientOpts = append(clientOpts, client.WithRetry(maxAttempts(), logRetry))
+
+	return client.New(append(clientOpts, opts...)...)
+}
+
+// maxAttempts returns the number of attempts of requests set in defaults, or the
+// default one.
+func maxAttempts() int {
+	attempts, err := strconv.Atoi(config.Defaults()[string(config.MaxAttempts)])
+	if err != nil || attempts <= 0 {
+		return client.DefaultMaxAttempts
+	}
+
+	return attempts
+}
+
+// exitError is returned by commands that have already printed their result, so
+// Execute only exits with the code (after trace file is written).
+type exitError struct {
+	code int
+}
+
+func (e exitError) Error() string {
+	return fmt.Sprintf("exit status %v", e.code)
+}
+
+// writeTraceFile writes requests made by the command to trace file, if set.
+func writeTraceFile() {
+	if harRecorder == nil {
+		return
+	}
+
+	if err := harRecorder.WriteFile(traceFileFlag); err != nil {
+		fmt.Println("Cannot write trace file:", err)
+	}
+}
+
 // Execute executes root command.
 func Execute(ver string) {
 	VERSION = version{
@@ -47,12 +159,23 @@ func Execute(ver string) {
 		RedmineAPIVersion: "3.3+",
 	}
 
-	RClient = &client.Client{
-		HTTPClient: &http.Client{},
-		UserAgent:  fmt.Sprintf("arcli/v%s", VERSION.Version),
-	}
+	var stop context.CancelFunc
+	ctx, stop = signal.NotifyContext(context.Background(), os.Interrupt)
+	go func() {
+		// the next interrupt terminates arcli, if the command does not stop by itself
+		<-ctx.Done()
+		stop()
+	}()
+
+	err := rootCmd.Execute()
+	stop()
+	writeTraceFile()
 
-	if err := rootCmd.Execute(); err != nil {
+	var exitErr exitError
+	if errors.As(err, &exitErr) {
+		os.Exit(exitErr.code)
+	}
+	if err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
@@ -62,17 +185,45 @@ func init() {
 	rootCmd.Flags().BoolVarP(&versionFlag, "version", "v", false,
 		"Current arcli and supported Redmine API version")
 
-	cobra.OnInitialize(func() { config.Setup() })
+	rootCmd.PersistentFlags().StringVar(&profileFlag, "profile", "",
+		fmt.Sprintf("Server profile to use (overrides %v and saved profile)", config.ProfileEnv))
+
+	rootCmd.PersistentFlags().DurationVar(&timeoutFlag, "timeout", defaultTimeout,
+		"Time limit of each request to Redmine server (0 for no limit)")
+
+	rootCmd.PersistentFlags().BoolVar(&debugFlag, "debug", false,
+		"Print requests to Redmine server with headers and timing (API key is masked)")
+	rootCmd.PersistentFlags().BoolVar(&debugBodyFlag, "debug-body", false,
+		"Print requests to Redmine server with bodies as well (implies --debug)")
+	rootCmd.PersistentFlags().StringVar(&traceFileFlag, "trace-file", "",
+		"Write requests to Redmine server to HAR file, which can be shared with server admins")
+
+	rootCmd.PersistentFlags().StringVarP(&outputFlag, "output", "o", string(utils.OutputTable),
+		fmt.Sprintf("Output format (%v, template=[template] or tpl:[templateName])",
+			strings.Join(utils.OutputFormats, ", ")))
+	rootCmd.PersistentFlags().StringVar(&templateFlag, "template", "",
+		"Go template executed for every listed item (overrides output format)")
+
+	cobra.OnInitialize(func() {
+		config.Setup(profileFlag)
+		setupClient()
+	})
 
 	rootCmd.AddCommand(
 		newTimeEntriesCmd(),
+		newTimerCmd(),
 		newStatusCmd(),
+		newReportCmd(),
 		newSearchCmd(),
 		newProjectsCmd(),
 		newIssuesCmd(),
 		newLoginCmd(),
 		newLogoutCmd(),
 		newAliasesCmd(),
+		newLeaveCmd(),
 		newDefaultsCmd(),
+		newProfilesCmd(),
+		newTemplatesCmd(),
+		newGitCmd(),
 	)
 }
@@ -5,8 +5,6 @@ import (
 
 	"github.com/jedib0t/go-pretty/table"
 	"github.com/spf13/cobra"
-
-	"github.com/mightymatth/arcli/utils"
 )
 
 func newSearchCmd() *cobra.Command {
@@ -18,7 +16,7 @@ func newSearchCmd() *cobra.Command {
 		Run:     searchFunc,
 	}
 
-	c.Flags().IntVarP(&searchOffset, "offset", "o", 0, "Offset from first result")
+	c.Flags().IntVar(&searchOffset, "offset", 0, "Offset from first result")
 	c.Flags().IntVarP(&searchLimit, "limit", "l", 5, "Limit of given search results")
 
 	return c
@@ -29,24 +27,26 @@ var (
 )
 
 func searchFunc(_ *cobra.Command, args []string) {
-	results, totalCount, err := RClient.GetSearchResults(args[0], searchOffset, searchLimit)
+	results, totalCount, err := RClient.GetSearchResults(ctx, args[0], searchOffset, searchLimit)
 	if err != nil {
 		fmt.Println("Search failed:", err)
 		return
 	}
 
-	if len(results) == 0 {
-		fmt.Println("No results found.")
-		return
-	}
+	if out.IsTable() {
+		if len(results) == 0 {
+			fmt.Println("No results found.")
+			return
+		}
 
-	fmt.Printf("Found %d results. Showing results from %d. to %d.\n",
-		totalCount, searchOffset+1, searchOffset+len(results))
+		fmt.Printf("Found %d results. Showing results from %d. to %d.\n",
+			totalCount, searchOffset+1, searchOffset+len(results))
+	}
 
-	t := utils.NewTable()
-	t.AppendHeader(table.Row{"Resource ID", "Title", "URL"})
+	rows := make([]table.Row, 0, len(results))
 	for _, result := range results {
-		t.AppendRow(table.Row{result.ID, result.Title, result.URL})
+		rows = append(rows, table.Row{result.ID, result.Title, result.URL})
 	}
-	t.Render()
+
+	render(results, table.Row{"Resource ID", "Title", "URL"}, rows)
 }
@@ -1,65 +1,154 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"github.com/jedib0t/go-pretty/table"
 	"github.com/mightymatth/arcli/client"
-	"github.com/mightymatth/arcli/utils"
 	"github.com/spf13/cobra"
 	"golang.org/x/sync/errgroup"
 	"strings"
 )
 
+var (
+	statusPeriods []string
+	statusFrom    string
+	statusTo      string
+)
+
 func newStatusCmd() *cobra.Command {
 	c := &cobra.Command{
 		Use:     "status",
 		Aliases: []string{"me"},
 		Short:   "Overall account info",
 		Long: `Shows user info and statistics of several periods showing: sum of tracked time hours,
-average hours per tracked time, number of issues and number of projects.`,
-		Run: statusFunc,
+average hours per tracked time, number of issues and number of projects.
+
+If working hours targets are set in defaults (dailytarget, weeklytarget or per-weekday
+targets such as fridaytarget), expected hours of every period are shown with remaining
+hours or overtime. Public holidays from file set in holidays default and leave days
+are not expected to be logged.`,
+		Example: `arcli status --period 2026-Q3 --period "last 14 days"
+arcli status --from 2026-09-01 --to 2026-09-15
+arcli defaults add dailytarget 8h && arcli defaults add fridaytarget 6h`,
+		Args: cobra.NoArgs,
+		Run:  statusFunc,
 	}
 
+	c.Flags().StringSliceVarP(&statusPeriods, "period", "p", nil,
+		"Periods to show ('this week', 'last 14 days', '2026-Q3', '2026-09', '2026-W41', '2026')")
+	c.Flags().StringVar(&statusFrom, "from", "",
+		"The first day of custom period ('today', 'yesterday', '2020-01-15')")
+	c.Flags().StringVar(&statusTo, "to", "today",
+		"The last day of custom period ('today', 'yesterday', '2020-01-15')")
+
 	return c
 }
 
-func statusFunc(_ *cobra.Command, _ []string) {
+func statusFunc(cmd *cobra.Command, _ []string) {
+	periods, err := statusPeriodsFromFlags(cmd)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	calendar, err := loadWorkCalendar()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
 	var user client.User
-	var today, yesterday, thisWeek, lastWeek, thisMonth, lastMonth periodData
+	data := make([]periodData, len(periods))
 
-	var g errgroup.Group
-	g.Go(asyncUserResult(&user))
-	g.Go(asyncPeriodResult(spentOnToday, &today))
-	g.Go(asyncPeriodResult(spentOnYesterday, &yesterday))
-	g.Go(asyncPeriodResult(spentOnThisWeek, &thisWeek))
-	g.Go(asyncPeriodResult(spentOnLastWeek, &lastWeek))
-	g.Go(asyncPeriodResult(spentOnThisMonth, &thisMonth))
-	g.Go(asyncPeriodResult(spentOnLastMonth, &lastMonth))
+	// the first failed request cancels the others
+	g, groupCtx := errgroup.WithContext(ctx)
+	g.Go(asyncUserResult(groupCtx, &user))
+	for i := range periods {
+		g.Go(asyncPeriodResult(groupCtx, periods[i], &data[i]))
+	}
 
-	err := g.Wait()
+	err = g.Wait()
 
 	if err != nil {
-		fmt.Println("Failed to get status:", err)
+		fmt.Println("Failed to get status:", describeError(err, ""))
 		return
 	}
 
-	fmt.Printf("[%d] %s %s (%s)\n", user.ID, user.FirstName, user.LastName, user.Email)
+	statuses := make([]periodStatus, 0, len(periods))
+	for i, p := range periods {
+		statuses = append(statuses, newPeriodStatus(p, data[i], calendar))
+	}
+
+	if out.IsTable() {
+		fmt.Printf("[%d] %s %s (%s)\n", user.ID, user.FirstName, user.LastName, user.Email)
+	}
+
+	header := table.Row{"PERIOD", "HOURS", "H/LOG", "# of I", "# of P"}
+	if calendar.targets.set {
+		header = append(header, "EXPECTED", "REMAINING", "OVERTIME")
+	}
+
+	rows := make([]table.Row, 0, len(statuses))
+	for _, status := range statuses {
+		row := table.Row{
+			status.Period, formatFloat(status.Hours), formatFloat(status.HoursPerLog),
+			status.IssueCount, status.ProjectCount,
+		}
+		if calendar.targets.set {
+			row = append(row, formatFloat(status.Expected), formatFloat(status.Remaining),
+				formatFloat(status.Overtime))
+		}
+		rows = append(rows, row)
+	}
+
+	user.APIKey = ""
+	render(statusReport{User: user, Periods: statuses}, header, rows)
+}
+
+// statusPeriodsFromFlags returns periods requested by flags, or default ones.
+func statusPeriodsFromFlags(cmd *cobra.Command) ([]period, error) {
+	day := today()
+
+	periods := make([]period, 0, len(statusPeriods)+1)
+	for _, name := range statusPeriods {
+		p, err := parsePeriod(name, day)
+		if err != nil {
+			return nil, err
+		}
+		periods = append(periods, p)
+	}
+
+	if anyFlagChanged(cmd, "from", "to") {
+		from, err := spentOnParse(statusFrom)
+		if statusFrom == "" || err != nil {
+			return nil, fmt.Errorf("provide valid from date of custom period")
+		}
+		to, err := spentOnParse(statusTo)
+		if err != nil {
+			return nil, fmt.Errorf("invalid to date: %v", err)
+		}
+		if to.Before(*from) {
+			return nil, fmt.Errorf("from date is after to date")
+		}
+
+		periods = append(periods, period{
+			Name: fmt.Sprintf("%v - %v", from.Format(client.DateTimeFormat), to.Format(client.DateTimeFormat)),
+			From: *from,
+			To:   *to,
+		})
+	}
 
-	t := utils.NewTable()
-	t.AppendHeader(table.Row{"PERIOD", "HOURS", "H/LOG", "# of I", "# of P"})
-	appendRow(t, "Today", today)
-	appendRow(t, "Yesterday", yesterday)
-	appendRow(t, "This Week", thisWeek)
-	appendRow(t, "Last Week", lastWeek)
-	appendRow(t, "This Month", thisMonth)
-	appendRow(t, "Last Month", lastMonth)
+	if len(periods) == 0 {
+		return defaultPeriods(day), nil
+	}
 
-	t.Render()
+	return periods, nil
 }
 
-func asyncUserResult(dest *client.User) func() error {
+func asyncUserResult(ctx context.Context, dest *client.User) func() error {
 	return func() error {
-		user, err := RClient.GetUser()
+		user, err := RClient.GetUser(ctx)
 		if err != nil {
 			return err
 		}
@@ -70,9 +159,9 @@ func asyncUserResult(dest *client.User) func() error {
 	}
 }
 
-func asyncPeriodResult(t timeSpentOn, dest *periodData) func() error {
+func asyncPeriodResult(ctx context.Context, p period, dest *periodData) func() error {
 	return func() error {
-		data, err := getDataForPeriod(t)
+		data, err := getDataForPeriod(ctx, p)
 		if err != nil {
 			return err
 		}
@@ -83,10 +172,11 @@ func asyncPeriodResult(t timeSpentOn, dest *periodData) func() error {
 	}
 }
 
-func getDataForPeriod(spentOn timeSpentOn) (periodData, error) {
-	entries, err := RClient.GetTimeEntries(fmt.Sprintf("spent_on=%s&user_id=me&limit=200", spentOn))
+func getDataForPeriod(ctx context.Context, p period) (periodData, error) {
+	entries, err := RClient.GetTimeEntries(ctx, fmt.Sprintf("from=%v&to=%v&user_id=me",
+		p.From.Format(client.DateTimeFormat), p.To.Format(client.DateTimeFormat)))
 	if err != nil {
-		return periodData{}, fmt.Errorf("cannot get period data (%v): %v", spentOn, err)
+		return periodData{}, fmt.Errorf("cannot get period data (%v): %w", p.Name, err)
 	}
 
 	var hoursSum float64
@@ -115,11 +205,25 @@ func getDataForPeriod(spentOn timeSpentOn) (periodData, error) {
 	}, nil
 }
 
-func appendRow(t table.Writer, period string, data periodData) {
-	t.AppendRow(table.Row{
-		period, formatFloat(data.hoursSum), formatFloat(data.hoursAvg),
-		data.issueCount, data.projectCount,
-	})
+func newPeriodStatus(p period, data periodData, calendar workCalendar) periodStatus {
+	status := periodStatus{
+		Period:       p.Name,
+		From:         *client.NewDateTime(p.From),
+		To:           *client.NewDateTime(p.To),
+		Hours:        data.hoursSum,
+		HoursPerLog:  data.hoursAvg,
+		IssueCount:   data.issueCount,
+		ProjectCount: data.projectCount,
+		Expected:     calendar.ExpectedPeriod(p),
+	}
+
+	if status.Hours < status.Expected {
+		status.Remaining = status.Expected - status.Hours
+	} else {
+		status.Overtime = status.Hours - status.Expected
+	}
+
+	return status
 }
 
 func formatFloat(num float64) string {
@@ -127,20 +231,28 @@ func formatFloat(num float64) string {
 	return strings.TrimRight(strings.TrimRight(s, "0"), ".")
 }
 
-type timeSpentOn string
-
-const (
-	spentOnToday     timeSpentOn = "t"
-	spentOnYesterday timeSpentOn = "ld"
-	spentOnThisWeek  timeSpentOn = "w"
-	spentOnLastWeek  timeSpentOn = "lw"
-	spentOnThisMonth timeSpentOn = "m"
-	spentOnLastMonth timeSpentOn = "lm"
-)
-
 type periodData struct {
 	hoursSum     float64
 	hoursAvg     float64
 	issueCount   int
 	projectCount int
 }
+
+// statusReport represents status in structured output formats.
+type statusReport struct {
+	User    client.User    `json:"user"`
+	Periods []periodStatus `json:"periods"`
+}
+
+type periodStatus struct {
+	Period       string          `json:"period"`
+	From         client.DateTime `json:"from"`
+	To           client.DateTime `json:"to"`
+	Hours        float64         `json:"hours"`
+	HoursPerLog  float64         `json:"hours_per_log"`
+	IssueCount   int             `json:"issue_count"`
+	ProjectCount int             `json:"project_count"`
+	Expected     float64         `json:"expected"`
+	Remaining    float64         `json:"remaining"`
+	Overtime     float64         `json:"overtime"`
+}
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/jedib0t/go-pretty/table"
+	"github.com/mightymatth/arcli/config"
+	"github.com/mightymatth/arcli/utils"
+	"github.com/spf13/cobra"
+)
+
+func newTemplatesCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:     "templates",
+		Aliases: []string{"template", "tpl"},
+		Short:   "Named Go templates for custom output (used with '-o tpl:[name]')",
+		Long: `Named Go templates for custom output, used with '-o tpl:[name]'. Templates are executed
+for every listed item (e.g. issue, time entry, project) and can use item fields and helper
+functions: date, since, hours, color, pad, padLeft, trunc, slug, upper, lower, trim, replace,
+join, default, issueURL and projectURL.
+
+Example:
+  arcli templates add branch 'feature/{{.ID}}-{{.Subject | slug}}'
+  arcli issues ls -o tpl:branch`,
+	}
+
+	c.AddCommand(newTemplatesListCmd())
+	c.AddCommand(newTemplatesAddCmd())
+	c.AddCommand(newTemplatesDeleteCmd())
+
+	return c
+}
+
+func newTemplatesListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls", "all"},
+		Short:   "List of all saved templates",
+		Run: func(cmd *cobra.Command, args []string) {
+			drawTemplates()
+		},
+	}
+}
+
+func newTemplatesAddCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "add [templateName] [template]",
+		Aliases: []string{"set", "new"},
+		Args:    validTemplatesAddArgs(),
+		Short:   "Add template entry",
+		Run: func(cmd *cobra.Command, args []string) {
+			err := config.SetTemplate(args[0], args[1])
+			if err != nil {
+				fmt.Println(err)
+				return
+			}
+			fmt.Printf("Template '%v' has been successfully saved.\n", args[0])
+		},
+	}
+}
+
+func newTemplatesDeleteCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "delete [templateName]",
+		Aliases: []string{"remove", "rm", "del"},
+		Args:    cobra.ExactArgs(1),
+		Short:   "Remove template entry",
+		Run: func(cmd *cobra.Command, args []string) {
+			_, found := config.GetTemplate(args[0])
+			if !found {
+				fmt.Printf("Template with name '%v' does not exist, so can't be deleted.\n", args[0])
+				return
+			}
+
+			err := config.SetTemplate(args[0], "")
+			if err != nil {
+				fmt.Println("Cannot delete template:", err)
+				return
+			}
+
+			fmt.Printf("Template with name '%v' has been deleted.\n", args[0])
+		},
+	}
+}
+
+func drawTemplates() {
+	templates := config.GetTemplates()
+	if len(templates) == 0 && out.IsTable() {
+		fmt.Println("You have no templates saved.")
+		fmt.Printf("These can be saved with: '%v'\n", newTemplatesAddCmd().UseLine())
+		return
+	}
+
+	rows := make([]table.Row, 0, len(templates))
+	for _, key := range sortedKeys(templates) {
+		rows = append(rows, table.Row{key, templates[key]})
+	}
+
+	render(templates, table.Row{"Name", "Template"}, rows)
+}
+
+func validTemplatesAddArgs() cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		err := cobra.ExactArgs(2)(cmd, args)
+		if err != nil {
+			return err
+		}
+
+		namePattern := "^[[:alnum:]-_]{1,30}$"
+		if !regexp.MustCompile(namePattern).MatchString(args[0]) {
+			return fmt.Errorf("template name must have pattern '%v'", namePattern)
+		}
+
+		_, err = utils.NewTemplateOutput(args[1], templateFuncs())
+		return err
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/mightymatth/arcli/config"
 
 	"github.com/mightymatth/arcli/client"
+	"github.com/mightymatth/arcli/parse"
 
 	"github.com/jedib0t/go-pretty/table"
 	"github.com/mightymatth/arcli/utils"
@@ -18,8 +19,9 @@ import (
 
 var (
 	limit    int
+	all      bool
 	spentOn  string
-	hours    float32
+	hours    string
 	activity string
 	comments string
 )
@@ -34,10 +36,19 @@ func newTimeEntriesCmd() *cobra.Command {
 	}
 
 	c.AddCommand(newTimeEntriesListCmd())
+	c.AddCommand(newTimeEntriesWeekCmd())
 	c.AddCommand(newTimeEntriesIssueCmd())
 	c.AddCommand(newTimeEntriesProjectCmd())
 	c.AddCommand(newTimeEntriesUpdateCmd())
 	c.AddCommand(newTimeEntriesDeleteCmd())
+	c.AddCommand(newTimeEntriesCopyCmd())
+	c.AddCommand(newTimeEntriesRepeatCmd())
+	c.AddCommand(newTimeEntriesUseCmd())
+	c.AddCommand(newEntryTemplatesCmd())
+	c.AddCommand(newTimeEntriesImportCmd())
+	c.AddCommand(newTimeEntriesFromGitCmd())
+	c.AddCommand(newTimeEntriesExportCmd())
+	c.AddCommand(newTimeEntriesGapsCmd())
 
 	return c
 }
@@ -52,28 +63,73 @@ func newTimeEntriesListCmd() *cobra.Command {
 
 	c.Flags().IntVarP(&limit, "limit", "l", 10,
 		"Limit number of results")
+	c.Flags().BoolVar(&all, "all", false,
+		"List all time entries (ignores limit)")
 
 	return c
 }
 
-func timeEntriesListFunc(cmd *cobra.Command, _ []string) {
-	limit := cmd.Flags().Lookup("limit").Value.String()
-	queryParams := fmt.Sprintf("limit=%s&user_id=me", limit)
-	logs, err := RClient.GetTimeEntries(queryParams)
-	if err != nil {
-		fmt.Println("Cannot get time entries:", err)
+func timeEntriesListFunc(_ *cobra.Command, _ []string) {
+	queryParams := fmt.Sprintf("limit=%d&user_id=me", limit)
+	if all {
+		queryParams = "user_id=me"
+	}
+
+	if !out.IsTable() {
+		logs, err := RClient.GetTimeEntries(ctx, queryParams)
+		if err != nil {
+			fmt.Println("Cannot get time entries:", describeError(err, ""))
+			return
+		}
+
+		drawTimeEntries(logs)
 		return
 	}
 
-	t := utils.NewTable()
-	t.AppendHeader(table.Row{"ID", "Project", "Issue ID",
-		"Activity", "Hours", "Spent on", "Comment"})
-	for _, log := range logs {
-		t.AppendRow(table.Row{log.ID, log.Project.Name, log.Issue.String(),
-			log.Activity.Name, log.Hours, relativeDateString(log.SpentOn),
-			log.Comments})
+	first := true
+	err := RClient.StreamTimeEntries(ctx, queryParams, func(entries []client.TimeEntry) error {
+		renderPage(timeEntriesHeader, timeEntryRows(entries), first)
+		first = false
+		return nil
+	})
+	if err != nil {
+		fmt.Println("Cannot get time entries:", describeError(err, ""))
+	}
+}
+
+var timeEntriesHeader = table.Row{"ID", "Project", "Issue ID", "Activity", "Hours", "Spent on", "Comment"}
+
+func drawTimeEntries(entries []client.TimeEntry) {
+	render(entries, timeEntriesHeader, timeEntryRows(entries))
+}
+
+func timeEntryRows(entries []client.TimeEntry) []table.Row {
+	rows := make([]table.Row, 0, len(entries))
+	for _, entry := range entries {
+		spentOn := entry.SpentOn.Format(client.DateTimeFormat)
+		if out.IsTable() {
+			spentOn = relativeDateString(entry.SpentOn)
+		}
+
+		rows = append(rows, table.Row{entry.ID, entry.Project.Name, entry.Issue.String(),
+			entry.Activity.Name, entry.Hours, spentOn, entry.Comments})
 	}
 
+	return rows
+}
+
+// drawTimeEntry prints time entry after it has been created or updated.
+func drawTimeEntry(entry client.TimeEntry, message string) {
+	if !out.IsTable() {
+		drawTimeEntries([]client.TimeEntry{entry})
+		return
+	}
+
+	fmt.Println(message)
+	t := utils.NewTable()
+	t.AppendHeader(table.Row{"Entry ID", "Project Name", "Issue ID", "Hours", "Activity", "Comment", "Spent On"})
+	t.AppendRow(table.Row{fmt.Sprint(entry.ID), entry.Project.Name, entry.Issue.String(),
+		fmt.Sprint(entry.Hours), entry.Activity.Name, entry.Comments, entry.SpentOn.Format(client.DayDateFormat)})
 	t.Render()
 }
 
@@ -87,9 +143,10 @@ func newTimeEntriesIssueCmd() *cobra.Command {
 	}
 
 	c.Flags().StringVarP(&spentOn, "date", "d", "today",
-		"The date the time was spent ('today', 'yesterday', '2020-01-15')")
-	c.Flags().Float32VarP(&hours, "hours", "t", 0,
-		"The number of spent hours")
+		"The date the time was spent ('today', 'monday', 'last friday', '-3d', '2w ago', '10/15', "+
+			"'2020-01-15'), or range of dates to add time entry on every day ('mon..wed')")
+	c.Flags().StringVarP(&hours, "hours", "t", "",
+		"The number of spent hours ('1.5', '1:30', '1h30m', '90m')")
 	c.Flags().StringVarP(&activity, "activity", "a", "",
 		"The name of activity for spent time (this overrides default config value)")
 	c.Flags().StringVarP(&comments, "message", "m", "",
@@ -109,9 +166,10 @@ func newTimeEntriesProjectCmd() *cobra.Command {
 	}
 
 	c.Flags().StringVarP(&spentOn, "date", "d", "today",
-		"The date the time was spent ('today', 'yesterday', '2020-01-15')")
-	c.Flags().Float32VarP(&hours, "hours", "t", 0,
-		"The number of spent hours")
+		"The date the time was spent ('today', 'monday', 'last friday', '-3d', '2w ago', '10/15', "+
+			"'2020-01-15'), or range of dates to add time entry on every day ('mon..wed')")
+	c.Flags().StringVarP(&hours, "hours", "t", "",
+		"The number of spent hours ('1.5', '1:30', '1h30m', '90m')")
 	c.Flags().StringVarP(&activity, "activity", "a", "",
 		"The name of activity for spent time (this overrides default config value)")
 	c.Flags().StringVarP(&comments, "message", "m", "",
@@ -123,63 +181,102 @@ func newTimeEntriesProjectCmd() *cobra.Command {
 
 func timeEntriesAddFunc(isProject bool) func(cmd *cobra.Command, args []string) {
 	return func(cmd *cobra.Command, args []string) {
-		id, _ := strconv.ParseInt(args[0], 10, 64)
+		var id int64
+		if isProject {
+			id, _ = strconv.ParseInt(args[0], 10, 64)
+		} else {
+			id = issueArgID(args)
+		}
 
-		activities, err := RClient.GetActivities()
+		activityID, err := resolveActivityID(activity)
 		if err != nil {
-			fmt.Println("Cannot get time entry activities")
+			fmt.Println(err)
 			return
 		}
 
-		if activity == "" {
-			activity = config.Defaults()[string(config.Activity)]
-			if activity == "" {
-				fmt.Println("Provide activity either by flag or setting default.")
-				return
-			}
-		}
-
-		activityID, exists := activities.Valid(activity)
-		if !exists {
-			fmt.Printf("Invalid activity (allowed ones: [%v])",
-				utils.PrintWithDelimiter(activities.Names()))
+		spentHours, err := parse.Hours(hours)
+		if err != nil {
+			fmt.Printf("Cannot parse hours value: %v\n", err)
 			return
 		}
 
-		spentOnTime, err := spentOnParse(spentOn)
+		days, err := dateParser().Range(spentOn)
 		if err != nil {
-			fmt.Printf("Cannot parse date value: %v", err)
+			fmt.Printf("Cannot parse date value: %v\n", err)
 			return
 		}
 
-		var entryPost *client.TimeEntryPost
+		entryPost := client.TimeEntryPost{
+			Hours:      float32(spentHours),
+			ActivityID: int(activityID),
+			Comments:   comments,
+		}
 		if isProject {
-			entryPost = &client.TimeEntryPost{
-				ProjectID:  int(id),
-				SpentOn:    *client.NewDateTime(*spentOnTime),
-				Hours:      hours,
-				ActivityID: int(activityID),
-				Comments:   comments,
-			}
+			entryPost.ProjectID = int(id)
 		} else {
-			entryPost = &client.TimeEntryPost{
-				IssueID:    int(id),
-				SpentOn:    *client.NewDateTime(*spentOnTime),
-				Hours:      hours,
-				ActivityID: int(activityID),
-				Comments:   comments,
-			}
+			entryPost.IssueID = int(id)
 		}
 
-		entry, err := RClient.AddTimeEntry(*entryPost)
+		addTimeEntries(entryPost, days)
+	}
+}
+
+// addTimeEntries adds time entry on every given day.
+func addTimeEntries(entryPost client.TimeEntryPost, days []time.Time) {
+	if len(days) == 1 {
+		entryPost.SpentOn = *client.NewDateTime(days[0])
+		entry, err := RClient.AddTimeEntry(ctx, entryPost)
 		if err != nil {
-			fmt.Printf("Cannot create time entry: %v\n", err)
+			fmt.Println("Cannot create time entry:", describeError(err, ""))
 			return
 		}
 
-		fmt.Println("Time entry created!")
-		entry.PrintTable()
+		drawTimeEntry(*entry, "Time entry created!")
+		return
+	}
+
+	entries := make([]client.TimeEntry, 0, len(days))
+	for _, day := range days {
+		entryPost.SpentOn = *client.NewDateTime(day)
+		entry, err := RClient.AddTimeEntry(ctx, entryPost)
+		if err != nil {
+			fmt.Printf("Cannot create time entry on %v: %v\n", day.Format(client.DayDateFormat), err)
+			continue
+		}
+		entries = append(entries, *entry)
+	}
+
+	if len(entries) == 0 {
+		return
+	}
+	if out.IsTable() {
+		fmt.Printf("%v of %v time entries created!\n", len(entries), len(days))
 	}
+	drawTimeEntries(entries)
+}
+
+// resolveActivityID returns ID of activity with given name, or of the default
+// activity if the name is empty.
+func resolveActivityID(activity string) (int64, error) {
+	if activity == "" {
+		activity = config.Defaults()[string(config.Activity)]
+		if activity == "" {
+			return 0, fmt.Errorf("provide activity either by flag or setting default")
+		}
+	}
+
+	activities, err := RClient.GetActivities(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("cannot get time entry activities")
+	}
+
+	activityID, exists := activities.Valid(activity)
+	if !exists {
+		return 0, fmt.Errorf("invalid activity (allowed ones: [%v])",
+			utils.PrintWithDelimiter(activities.Names()))
+	}
+
+	return activityID, nil
 }
 
 func newTimeEntriesUpdateCmd() *cobra.Command {
@@ -192,9 +289,9 @@ func newTimeEntriesUpdateCmd() *cobra.Command {
 	}
 
 	c.Flags().StringVarP(&spentOn, "date", "d", "today",
-		"The date the time was spent ('today', 'yesterday', '2020-01-15')")
-	c.Flags().Float32VarP(&hours, "hours", "t", 0,
-		"The number of spent hours")
+		"The date the time was spent ('today', 'monday', 'last friday', '-3d', '10/15', '2020-01-15')")
+	c.Flags().StringVarP(&hours, "hours", "t", "",
+		"The number of spent hours ('1.5', '1:30', '1h30m', '90m')")
 	c.Flags().StringVarP(&activity, "activity", "a", "",
 		"The name of activity for spent time (this overrides default config value)")
 	c.Flags().StringVarP(&comments, "message", "m", "",
@@ -209,7 +306,7 @@ func timeEntriesUpdateFunc() func(cmd *cobra.Command, args []string) {
 		var entryUpdate client.TimeEntryPost
 
 		if activity != "" {
-			activities, err := RClient.GetActivities()
+			activities, err := RClient.GetActivities(ctx)
 			if err != nil {
 				fmt.Println("Cannot get time entry activities")
 				return
@@ -242,22 +339,27 @@ func timeEntriesUpdateFunc() func(cmd *cobra.Command, args []string) {
 			entryUpdate.Comments = comments
 		}
 
-		if hours != 0 {
-			entryUpdate.Hours = hours
+		if hours != "" {
+			spentHours, err := parse.Hours(hours)
+			if err != nil {
+				fmt.Printf("Cannot parse hours value: %v\n", err)
+				return
+			}
+			entryUpdate.Hours = float32(spentHours)
 		}
 
-		err := RClient.UpdateTimeEntry(int(entryID), entryUpdate)
+		err := RClient.UpdateTimeEntry(ctx, int(entryID), entryUpdate)
 		if err != nil {
-			fmt.Printf("Cannot update time entry: %v\n", err)
+			fmt.Println("Cannot update time entry:", describeError(err, fmt.Sprintf("time entry %v", entryID)))
 			return
 		}
-		fmt.Println("Time entry updated!")
-
-		updatedEntry, err := RClient.GetTimeEntry(int(entryID))
+		updatedEntry, err := RClient.GetTimeEntry(ctx, int(entryID))
 		if err != nil {
-			fmt.Printf("Time entry with ID %d cannot be fetched: %v", entryID, err)
+			fmt.Printf("Time entry updated, but it cannot be fetched: %v\n", err)
+			return
 		}
-		updatedEntry.PrintTable()
+
+		drawTimeEntry(*updatedEntry, "Time entry updated!")
 	}
 }
 
@@ -311,9 +413,9 @@ func timeEntriesDeleteFunc(_ *cobra.Command, args []string) {
 	for _, arg := range args {
 		entryID, _ := strconv.ParseInt(arg, 10, 64)
 
-		err := RClient.DeleteTimeEntry(int(entryID))
+		err := RClient.DeleteTimeEntry(ctx, int(entryID))
 		if err != nil {
-			fmt.Println("Cannot delete time entry:", err)
+			fmt.Println("Cannot delete time entry:", describeError(err, fmt.Sprintf("time entry %v", entryID)))
 			return
 		}
 
@@ -322,22 +424,19 @@ func timeEntriesDeleteFunc(_ *cobra.Command, args []string) {
 }
 
 func spentOnModify(spentOn string) (string, error) {
-	var modified string
-	switch spentOn {
-	case "today":
-		modified = timeNow.Format(client.DateTimeFormat)
-	case "yesterday":
-		modified = timeNow.AddDate(0, 0, -1).Format(client.DateTimeFormat)
-	default:
-		_, err := time.Parse(client.DateTimeFormat, spentOn)
-		if err != nil {
-			return "", fmt.Errorf("invalid date format (use '%v' instead)",
-				client.DateTimeFormat)
-		}
-		modified = spentOn
+	date, err := dateParser().Date(spentOn)
+	if err != nil {
+		return "", err
 	}
 
-	return modified, nil
+	return date.Format(client.DateTimeFormat), nil
+}
+
+// dateParser returns parser of dates relative to today, with the order of month
+// and day in short dates set in defaults.
+func dateParser() parse.DateParser {
+	order, _ := parse.ParseDateOrder(config.Defaults()[string(config.DateOrder)])
+	return parse.DateParser{Now: timeNow, Order: order}
 }
 
 func spentOnParse(spentOn string) (*time.Time, error) {
@@ -0,0 +1,388 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jedib0t/go-pretty/text"
+	"github.com/mightymatth/arcli/client"
+	"github.com/mightymatth/arcli/config"
+	"github.com/mightymatth/arcli/parse"
+	"github.com/mightymatth/arcli/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// timerStateName is the name of state file that keeps running timer.
+	timerStateName = "timer"
+	// defaultTimerRounding is used if rounding is not set in defaults.
+	defaultTimerRounding = 15 * time.Minute
+	// timerLongRunning is the duration after which the timer is considered forgotten.
+	timerLongRunning = 10 * time.Hour
+)
+
+var (
+	timerComments string
+	timerActivity string
+	timerYes      bool
+)
+
+// timer represents time tracking in progress, saved between command runs.
+type timer struct {
+	IssueID   int64         `json:"issue_id"`
+	Comments  string        `json:"comments,omitempty"`
+	Activity  string        `json:"activity,omitempty"`
+	StartedAt time.Time     `json:"started_at"`
+	ResumedAt *time.Time    `json:"resumed_at,omitempty"`
+	Elapsed   time.Duration `json:"elapsed"`
+}
+
+// Running reports whether timer is not paused.
+func (t *timer) Running() bool {
+	return t.ResumedAt != nil
+}
+
+// Duration returns tracked time without pauses.
+func (t *timer) Duration(now time.Time) time.Duration {
+	if t.Running() {
+		return t.Elapsed + now.Sub(*t.ResumedAt)
+	}
+	return t.Elapsed
+}
+
+// Pause stops tracking time, keeping the time tracked so far.
+func (t *timer) Pause(now time.Time) {
+	t.Elapsed = t.Duration(now)
+	t.ResumedAt = nil
+}
+
+// Resume continues tracking time.
+func (t *timer) Resume(now time.Time) {
+	t.ResumedAt = &now
+}
+
+// Warnings returns reasons why tracked time might be wrong.
+func (t *timer) Warnings(now time.Time) []string {
+	var warnings []string
+
+	startDay := t.StartedAt.Format(client.DateTimeFormat)
+	if startDay != now.Format(client.DateTimeFormat) {
+		warnings = append(warnings, fmt.Sprintf("timer has been running across midnight (started on %v)",
+			t.StartedAt.Format(client.DayDateFormat)))
+	}
+
+	if duration := t.Duration(now); duration > timerLongRunning {
+		warnings = append(warnings, fmt.Sprintf("timer has been running for %v, did you forget to stop it?",
+			utils.FormatHours(duration.Hours())))
+	}
+
+	return warnings
+}
+
+func newTimerCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:     "timer",
+		Aliases: []string{"t"},
+		Short:   "Track time on issue with a timer that turns into a time entry",
+	}
+
+	c.AddCommand(newTimerStartCmd())
+	c.AddCommand(newTimerStatusCmd())
+	c.AddCommand(newTimerPauseCmd())
+	c.AddCommand(newTimerResumeCmd())
+	c.AddCommand(newTimerStopCmd())
+	c.AddCommand(newTimerCancelCmd())
+
+	return c
+}
+
+func newTimerStartCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "start [id]",
+		Args:  validIssueArgs(),
+		Short: "Start timer on issue",
+		Run:   timerStartFunc,
+	}
+
+	c.Flags().StringVarP(&timerComments, "message", "m", "",
+		"Short comment")
+	c.Flags().StringVarP(&timerActivity, "activity", "a", "",
+		"The name of activity for spent time (this overrides default config value)")
+
+	return c
+}
+
+func timerStartFunc(_ *cobra.Command, args []string) {
+	var running timer
+	found, err := config.LoadState(timerStateName, &running)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	if found {
+		fmt.Printf("Timer is already running on issue %v (stop or cancel it first).\n", running.IssueID)
+		return
+	}
+
+	issueID := issueArgID(args)
+	issue, err := RClient.GetIssue(ctx, issueID)
+	if err != nil {
+		fmt.Println("Cannot fetch issue:", describeError(err, fmt.Sprintf("issue %v", issueID)))
+		return
+	}
+
+	// activity is resolved again on stop, but it is better to fail before tracking starts
+	_, err = resolveActivityID(timerActivity)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	now := time.Now()
+	err = config.SaveState(timerStateName, timer{
+		IssueID:   issueID,
+		Comments:  timerComments,
+		Activity:  timerActivity,
+		StartedAt: now,
+		ResumedAt: &now,
+	})
+	if err != nil {
+		fmt.Println("Cannot save timer:", err)
+		return
+	}
+
+	fmt.Printf("Timer started on [%v] %v\n", text.FgGreen.Sprint(issue.ID), text.FgGreen.Sprint(issue.Subject))
+}
+
+func newTimerStatusCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "status",
+		Aliases: []string{"st", "show"},
+		Short:   "Show running timer",
+		Run: func(cmd *cobra.Command, args []string) {
+			running, found := loadTimer()
+			if !found {
+				return
+			}
+
+			now := time.Now()
+			state := text.FgGreen.Sprint("running")
+			if !running.Running() {
+				state = text.FgYellow.Sprint("paused")
+			}
+
+			fmt.Printf("Timer on issue %v is %v: %v (started %v)\n", text.FgGreen.Sprint(running.IssueID),
+				state, utils.FormatHours(running.Duration(now).Hours()),
+				running.StartedAt.Format(dateTimeMinutesFormat))
+			if running.Comments != "" {
+				fmt.Printf("Comment: %v\n", running.Comments)
+			}
+			printTimerWarnings(running, now)
+		},
+	}
+}
+
+func newTimerPauseCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "pause",
+		Short: "Pause running timer",
+		Run: func(cmd *cobra.Command, args []string) {
+			running, found := loadTimer()
+			if !found {
+				return
+			}
+
+			if !running.Running() {
+				fmt.Println("Timer is already paused.")
+				return
+			}
+
+			running.Pause(time.Now())
+
+			err := config.SaveState(timerStateName, running)
+			if err != nil {
+				fmt.Println("Cannot save timer:", err)
+				return
+			}
+
+			fmt.Printf("Timer paused at %v.\n", utils.FormatHours(running.Elapsed.Hours()))
+		},
+	}
+}
+
+func newTimerResumeCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "resume",
+		Aliases: []string{"continue"},
+		Short:   "Resume paused timer",
+		Run: func(cmd *cobra.Command, args []string) {
+			running, found := loadTimer()
+			if !found {
+				return
+			}
+
+			if running.Running() {
+				fmt.Println("Timer is already running.")
+				return
+			}
+
+			running.Resume(time.Now())
+
+			err := config.SaveState(timerStateName, running)
+			if err != nil {
+				fmt.Println("Cannot save timer:", err)
+				return
+			}
+
+			fmt.Printf("Timer resumed at %v.\n", utils.FormatHours(running.Elapsed.Hours()))
+		},
+	}
+}
+
+func newTimerStopCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:     "stop",
+		Aliases: []string{"done", "finish"},
+		Short:   "Stop timer and create time entry",
+		Long: `Stop timer and create time entry. Tracked time is rounded to the increment set by
+'rounding' default (15m if not set) and logged on the day the timer was started.`,
+		Run: timerStopFunc,
+	}
+
+	c.Flags().StringVarP(&timerComments, "message", "m", "",
+		"Short comment (overrides the one given on start)")
+	c.Flags().StringVarP(&timerActivity, "activity", "a", "",
+		"The name of activity for spent time (overrides the one given on start)")
+	c.Flags().BoolVarP(&timerYes, "yes", "y", false,
+		"Create time entry without confirmation, even if tracked time looks wrong")
+
+	return c
+}
+
+func timerStopFunc(cmd *cobra.Command, _ []string) {
+	running, found := loadTimer()
+	if !found {
+		return
+	}
+
+	now := time.Now()
+	if printTimerWarnings(running, now) && !timerYes && !utils.Confirm("Create time entry anyway?") {
+		return
+	}
+
+	rounding, err := timerRounding()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	comments := running.Comments
+	if cmd.Flags().Changed("message") {
+		comments = timerComments
+	}
+
+	activity := running.Activity
+	if timerActivity != "" {
+		activity = timerActivity
+	}
+
+	activityID, err := resolveActivityID(activity)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	duration := roundDuration(running.Duration(now), rounding)
+	spentOn, _ := time.Parse(client.DateTimeFormat, running.StartedAt.Format(client.DateTimeFormat))
+
+	entry, err := RClient.AddTimeEntry(ctx, client.TimeEntryPost{
+		IssueID:    int(running.IssueID),
+		SpentOn:    *client.NewDateTime(spentOn),
+		Hours:      float32(duration.Hours()),
+		ActivityID: int(activityID),
+		Comments:   comments,
+	})
+	if err != nil {
+		fmt.Printf("Cannot create time entry (timer is kept): %v\n", err)
+		return
+	}
+
+	err = config.RemoveState(timerStateName)
+	if err != nil {
+		fmt.Println("Cannot remove timer:", err)
+	}
+
+	drawTimeEntry(*entry, fmt.Sprintf("Timer stopped, time entry of %v created!",
+		utils.FormatHours(duration.Hours())))
+}
+
+func newTimerCancelCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "cancel",
+		Aliases: []string{"discard", "reset"},
+		Short:   "Discard timer without creating time entry",
+		Run: func(cmd *cobra.Command, args []string) {
+			_, found := loadTimer()
+			if !found {
+				return
+			}
+
+			err := config.RemoveState(timerStateName)
+			if err != nil {
+				fmt.Println("Cannot remove timer:", err)
+				return
+			}
+
+			fmt.Println("Timer discarded.")
+		},
+	}
+}
+
+// loadTimer loads saved timer and prints message if there is none.
+func loadTimer() (timer, bool) {
+	var running timer
+	found, err := config.LoadState(timerStateName, &running)
+	if err != nil {
+		fmt.Println(err)
+		return running, false
+	}
+	if !found {
+		fmt.Println("There is no timer running. It can be started with: 'arcli timer start [id]'")
+		return running, false
+	}
+
+	return running, true
+}
+
+// printTimerWarnings prints timer warnings and returns true if there were any.
+func printTimerWarnings(t timer, now time.Time) bool {
+	warnings := t.Warnings(now)
+	for _, warning := range warnings {
+		fmt.Println(text.FgYellow.Sprint("Warning: " + warning))
+	}
+
+	return len(warnings) != 0
+}
+
+func timerRounding() (time.Duration, error) {
+	value := config.Defaults()[string(config.Rounding)]
+	if value == "" {
+		return defaultTimerRounding, nil
+	}
+
+	rounding, err := parse.Duration(value)
+	if err != nil || rounding <= 0 {
+		return 0, fmt.Errorf("invalid rounding default '%v' (use duration such as '15m', '0:15' or '0.25')", value)
+	}
+
+	return rounding, nil
+}
+
+// roundDuration rounds duration to the nearest increment, but never below one increment.
+func roundDuration(duration, increment time.Duration) time.Duration {
+	rounded := duration.Round(increment)
+	if rounded < increment {
+		return increment
+	}
+	return rounded
+}
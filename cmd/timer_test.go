@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mightymatth/arcli/config"
+	"github.com/spf13/viper"
+)
+
+func TestRoundDuration(t *testing.T) {
+	tests := []struct {
+		duration, increment, want time.Duration
+	}{
+		{0, 15 * time.Minute, 15 * time.Minute},
+		{time.Second, 15 * time.Minute, 15 * time.Minute},
+		{7 * time.Minute, 15 * time.Minute, 15 * time.Minute},
+		{15 * time.Minute, 15 * time.Minute, 15 * time.Minute},
+		{22*time.Minute + 29*time.Second, 15 * time.Minute, 15 * time.Minute},
+		{22*time.Minute + 30*time.Second, 15 * time.Minute, 30 * time.Minute},
+		{52 * time.Minute, 15 * time.Minute, 45 * time.Minute},
+		{53 * time.Minute, 15 * time.Minute, time.Hour},
+		{2*time.Hour + 4*time.Minute, 6 * time.Minute, 2*time.Hour + 6*time.Minute},
+		{2*time.Hour + 2*time.Minute, 6 * time.Minute, 2 * time.Hour},
+		{90 * time.Minute, time.Hour, 2 * time.Hour},
+		{time.Minute, time.Minute, time.Minute},
+	}
+
+	for _, tt := range tests {
+		if got := roundDuration(tt.duration, tt.increment); got != tt.want {
+			t.Errorf("roundDuration(%v, %v) = %v, want %v", tt.duration, tt.increment, got, tt.want)
+		}
+	}
+}
+
+func TestTimerDuration(t *testing.T) {
+	start := time.Date(2026, time.October, 16, 9, 0, 0, 0, time.UTC)
+	at := func(hour, minute int) time.Time {
+		return start.Add(time.Duration(hour-9)*time.Hour + time.Duration(minute)*time.Minute)
+	}
+
+	running := timer{IssueID: 1, StartedAt: start}
+	running.Resume(start)
+
+	steps := []struct {
+		action string
+		now    time.Time
+		want   time.Duration
+	}{
+		{"", at(9, 0), 0},
+		{"", at(10, 30), 90 * time.Minute},
+		{"pause", at(11, 0), 2 * time.Hour},
+		{"", at(12, 0), 2 * time.Hour},
+		{"resume", at(13, 0), 2 * time.Hour},
+		{"", at(13, 45), 2*time.Hour + 45*time.Minute},
+		{"pause", at(14, 0), 3 * time.Hour},
+		{"resume", at(14, 30), 3 * time.Hour},
+		{"", at(15, 0), 3*time.Hour + 30*time.Minute},
+	}
+
+	for _, step := range steps {
+		switch step.action {
+		case "pause":
+			running.Pause(step.now)
+			if running.Running() {
+				t.Errorf("timer is running after pause at %v", step.now.Format("15:04"))
+			}
+		case "resume":
+			running.Resume(step.now)
+			if !running.Running() {
+				t.Errorf("timer is paused after resume at %v", step.now.Format("15:04"))
+			}
+		}
+
+		if got := running.Duration(step.now); got != step.want {
+			t.Errorf("%v at %v: Duration() = %v, want %v", step.action, step.now.Format("15:04"), got, step.want)
+		}
+	}
+}
+
+func TestTimerWarnings(t *testing.T) {
+	start := time.Date(2026, time.October, 16, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		start    time.Time
+		elapsed  time.Duration
+		paused   bool
+		now      time.Time
+		warnings []string
+	}{
+		{"short", start, 0, false, start.Add(2 * time.Hour), nil},
+		{"just below long", start, 0, false, start.Add(timerLongRunning), nil},
+		{"long", start, 0, false, start.Add(timerLongRunning + time.Minute), []string{"running for"}},
+		{"across midnight", start.Add(14 * time.Hour), 0, false, start.Add(16 * time.Hour),
+			[]string{"across midnight"}},
+		{"across midnight and long", start, 0, false, start.Add(24 * time.Hour),
+			[]string{"across midnight", "running for"}},
+		{"paused across midnight", start, time.Hour, true, start.Add(30 * time.Hour),
+			[]string{"across midnight"}},
+		{"long with pauses", start, 9 * time.Hour, false, start.Add(11 * time.Hour), []string{"running for"}},
+	}
+
+	for _, tt := range tests {
+		running := timer{IssueID: 1, StartedAt: tt.start, Elapsed: tt.elapsed}
+		if !tt.paused {
+			// time before the last resume is in elapsed
+			running.Resume(tt.start.Add(tt.elapsed))
+		}
+
+		got := running.Warnings(tt.now)
+		if len(got) != len(tt.warnings) {
+			t.Errorf("%v: Warnings() = %q, want ones containing %q", tt.name, got, tt.warnings)
+			continue
+		}
+		for i, warning := range tt.warnings {
+			if !strings.Contains(got[i], warning) {
+				t.Errorf("%v: warning %q does not contain %q", tt.name, got[i], warning)
+			}
+		}
+	}
+}
+
+func TestTimerRounding(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"", defaultTimerRounding, false},
+		{"15m", 15 * time.Minute, false},
+		{"6m", 6 * time.Minute, false},
+		{"0:30", 30 * time.Minute, false},
+		{"0.25", 15 * time.Minute, false},
+		{"1h", time.Hour, false},
+		{"0", 0, true},
+		{"0m", 0, true},
+		{"-15m", 0, true},
+		{"quarter", 0, true},
+		{"15", 15 * time.Hour, false},
+	}
+
+	t.Cleanup(viper.Reset)
+	for _, tt := range tests {
+		defaults := map[string]string{}
+		if tt.value != "" {
+			defaults[string(config.Rounding)] = tt.value
+		}
+		viper.Set(config.Key(config.DefaultsMap), defaults)
+
+		got, err := timerRounding()
+		if (err != nil) != tt.wantErr {
+			t.Errorf("timerRounding() with %q error = %v, want error %v", tt.value, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("timerRounding() with %q = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,585 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jedib0t/go-pretty/table"
+	"github.com/mightymatth/arcli/client"
+	"github.com/mightymatth/arcli/config"
+	"github.com/mightymatth/arcli/parse"
+	"github.com/mightymatth/arcli/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	timesheetWeek string
+	timesheetEdit bool
+)
+
+// timesheetDays is the number of days (columns) in weekly timesheet.
+const timesheetDays = 7
+
+var isoWeekRegex = regexp.MustCompile(`^(\d{4})-?[Ww](\d{1,2})$`)
+
+// timesheet represents time entries of a single week, grouped by issue (or
+// project) and activity.
+type timesheet struct {
+	Week   string                 `json:"week"`
+	From   client.DateTime        `json:"from"`
+	To     client.DateTime        `json:"to"`
+	Rows   []*timesheetRow        `json:"rows"`
+	Totals [timesheetDays]float64 `json:"totals"`
+	Total  float64                `json:"total"`
+}
+
+// timesheetRow represents hours spent on issue or project with one activity
+// through the week.
+type timesheetRow struct {
+	IssueID   int64                  `json:"issue_id,omitempty"`
+	Subject   string                 `json:"subject,omitempty"`
+	ProjectID int64                  `json:"project_id"`
+	Project   string                 `json:"project"`
+	Activity  string                 `json:"activity"`
+	Hours     [timesheetDays]float64 `json:"hours"`
+	Total     float64                `json:"total"`
+
+	activityID int64
+	entries    [timesheetDays][]client.TimeEntry
+}
+
+// Target returns row target as written in timesheet editor ('i:<id>' or 'p:<id>').
+func (r *timesheetRow) Target() string {
+	if r.IssueID != 0 {
+		return fmt.Sprintf("i:%v", r.IssueID)
+	}
+	return fmt.Sprintf("p:%v", r.ProjectID)
+}
+
+func (r *timesheetRow) key() string {
+	return r.Target() + "|" + r.Activity
+}
+
+func newTimeEntriesWeekCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:     "week",
+		Aliases: []string{"w", "timesheet"},
+		Short:   "Show weekly timesheet",
+		Long: `Show time entries of a week as a grid with issues and projects as rows and days
+as columns. With --edit flag, the grid is opened in the editor set by VISUAL or EDITOR
+environment variable and changes are applied as time entries after confirmation.`,
+		Args: cobra.NoArgs,
+		Run:  timesheetFunc,
+	}
+
+	c.Flags().StringVarP(&timesheetWeek, "week", "w", "this",
+		"ISO week ('this', 'last', '2026-W41')")
+	c.Flags().BoolVarP(&timesheetEdit, "edit", "e", false,
+		"Edit timesheet in the editor")
+
+	return c
+}
+
+func timesheetFunc(_ *cobra.Command, _ []string) {
+	monday, err := parseISOWeek(timesheetWeek, time.Now())
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	sheet, err := getTimesheet(monday)
+	if err != nil {
+		fmt.Println("Cannot get time entries:", err)
+		return
+	}
+
+	if !timesheetEdit {
+		drawTimesheet(sheet)
+		return
+	}
+
+	editTimesheet(sheet)
+}
+
+// parseISOWeek returns Monday of the ISO week given as 'this', 'last' or '2026-W41'.
+func parseISOWeek(week string, now time.Time) (time.Time, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	thisMonday := today.AddDate(0, 0, -(int(today.Weekday())+6)%7)
+
+	switch strings.ToLower(week) {
+	case "", "this":
+		return thisMonday, nil
+	case "last":
+		return thisMonday.AddDate(0, 0, -7), nil
+	}
+
+	match := isoWeekRegex.FindStringSubmatch(week)
+	if match == nil {
+		return time.Time{}, fmt.Errorf("invalid week '%v' (use 'this', 'last' or '2026-W41')", week)
+	}
+
+	year, _ := strconv.Atoi(match[1])
+	number, _ := strconv.Atoi(match[2])
+
+	// January 4th is always in the first ISO week
+	jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, time.UTC)
+	monday := jan4.AddDate(0, 0, -(int(jan4.Weekday())+6)%7+(number-1)*7)
+
+	if y, w := monday.ISOWeek(); number < 1 || y != year || w != number {
+		return time.Time{}, fmt.Errorf("year %v has no week %v", year, number)
+	}
+
+	return monday, nil
+}
+
+func getTimesheet(monday time.Time) (*timesheet, error) {
+	sunday := monday.AddDate(0, 0, timesheetDays-1)
+	year, week := monday.ISOWeek()
+
+	entries, err := RClient.GetTimeEntries(ctx, fmt.Sprintf("user_id=me&from=%v&to=%v",
+		monday.Format(client.DateTimeFormat), sunday.Format(client.DateTimeFormat)))
+	if err != nil {
+		return nil, err
+	}
+
+	sheet := &timesheet{
+		Week: fmt.Sprintf("%d-W%02d", year, week),
+		From: *client.NewDateTime(monday),
+		To:   *client.NewDateTime(sunday),
+		Rows: []*timesheetRow{},
+	}
+
+	rows := make(map[string]*timesheetRow)
+	for _, entry := range entries {
+		day := int(entry.SpentOn.Sub(monday).Hours() / 24)
+		if day < 0 || day >= timesheetDays {
+			continue
+		}
+
+		row := &timesheetRow{
+			IssueID:   entry.Issue.ID,
+			ProjectID: entry.Project.ID,
+			Project:   entry.Project.Name,
+			Activity:  entry.Activity.Name,
+
+			activityID: entry.Activity.ID,
+		}
+		if existing, found := rows[row.key()]; found {
+			row = existing
+		} else {
+			rows[row.key()] = row
+			sheet.Rows = append(sheet.Rows, row)
+		}
+
+		row.entries[day] = append(row.entries[day], entry)
+		row.Hours[day] += entry.Hours
+		row.Total += entry.Hours
+		sheet.Totals[day] += entry.Hours
+		sheet.Total += entry.Hours
+	}
+
+	sort.SliceStable(sheet.Rows, func(i, j int) bool {
+		a, b := sheet.Rows[i], sheet.Rows[j]
+		if a.Project != b.Project {
+			return a.Project < b.Project
+		}
+		if a.IssueID != b.IssueID {
+			return a.IssueID < b.IssueID
+		}
+		return a.Activity < b.Activity
+	})
+
+	fillTimesheetSubjects(sheet)
+
+	return sheet, nil
+}
+
+// fillTimesheetSubjects sets issue subjects to rows.
+func fillTimesheetSubjects(sheet *timesheet) {
+	ids := make([]int64, 0, len(sheet.Rows))
+	for _, row := range sheet.Rows {
+		if row.IssueID != 0 {
+			ids = append(ids, row.IssueID)
+		}
+	}
+
+	subjects := issueSubjects(ids)
+	for _, row := range sheet.Rows {
+		row.Subject = subjects[row.IssueID]
+	}
+}
+
+func (s *timesheet) day(i int) time.Time {
+	return s.From.AddDate(0, 0, i)
+}
+
+func drawTimesheet(sheet *timesheet) {
+	header := table.Row{"Issue", "Project", "Activity"}
+	for i := 0; i < timesheetDays; i++ {
+		header = append(header, sheet.day(i).Format("Mon 02"))
+	}
+	header = append(header, "Total")
+
+	rows := make([]table.Row, 0, len(sheet.Rows)+1)
+	for _, r := range sheet.Rows {
+		issue := "-"
+		if r.IssueID != 0 {
+			issue = strings.TrimSpace(fmt.Sprintf("#%v %v", r.IssueID, r.Subject))
+		}
+
+		row := table.Row{issue, r.Project, r.Activity}
+		for _, hours := range r.Hours {
+			row = append(row, formatTimesheetHours(hours))
+		}
+		rows = append(rows, append(row, formatTimesheetHours(r.Total)))
+	}
+
+	totals := table.Row{"Total", "", ""}
+	for _, hours := range sheet.Totals {
+		totals = append(totals, formatTimesheetHours(hours))
+	}
+	rows = append(rows, append(totals, formatTimesheetHours(sheet.Total)))
+
+	if out.IsTable() {
+		fmt.Printf("Week %v (%v - %v)\n", sheet.Week, sheet.From.Format(client.DayDateFormat),
+			sheet.To.Format(client.DayDateFormat))
+	}
+
+	render(sheet, header, rows)
+}
+
+// formatTimesheetHours formats hours with at most two decimals, or '-' if zero.
+func formatTimesheetHours(hours float64) string {
+	if math.Abs(hours) < 0.005 {
+		return "-"
+	}
+
+	s := strconv.FormatFloat(hours, 'f', 2, 64)
+	return strings.TrimRight(strings.TrimRight(s, "0"), ".")
+}
+
+// parseTimesheetHours parses cell value given as decimal hours ('1.5'), hours and
+// minutes ('1:30') or duration ('1h30m'). Empty cell and '-' are zero hours.
+func parseTimesheetHours(value string) (float64, error) {
+	if value == "" || value == "-" {
+		return 0, nil
+	}
+
+	return parse.Hours(value)
+}
+
+func editTimesheet(sheet *timesheet) {
+	activities, err := RClient.GetActivities(ctx)
+	if err != nil {
+		fmt.Println("Cannot get time entry activities:", err)
+		return
+	}
+
+	text := sheet.editorText()
+	var changes []timesheetChange
+	for {
+		edited, err := utils.EditText(text, "timesheet-*.txt")
+		if err != nil {
+			fmt.Println("Cannot edit timesheet:", err)
+			return
+		}
+
+		changes, err = sheet.diff(edited, activities)
+		if err == nil {
+			break
+		}
+
+		fmt.Println("Cannot apply timesheet:", err)
+		if !utils.Confirm("Edit again?") {
+			return
+		}
+		text = edited
+	}
+
+	if len(changes) == 0 {
+		fmt.Println("Timesheet has not been changed.")
+		return
+	}
+
+	fmt.Println("The following changes will be applied:")
+	for _, change := range changes {
+		fmt.Println("  " + change.description)
+	}
+	if !utils.Confirm(fmt.Sprintf("Apply %v changes?", len(changes))) {
+		return
+	}
+
+	failed := 0
+	for _, change := range changes {
+		err = change.apply()
+		if err != nil {
+			failed++
+			fmt.Printf("Cannot %v: %v\n", change.description, err)
+		}
+	}
+
+	if failed != 0 {
+		fmt.Printf("%v of %v changes applied.\n", len(changes)-failed, len(changes))
+		return
+	}
+	fmt.Println("Timesheet updated!")
+}
+
+// editorText returns timesheet as text that can be edited and parsed by diff.
+func (s *timesheet) editorText() string {
+	header := []string{"# TARGET", "ACTIVITY"}
+	for i := 0; i < timesheetDays; i++ {
+		header = append(header, strings.ToUpper(s.day(i).Format("Mon 02")))
+	}
+
+	lines := [][]string{header}
+	for _, r := range s.Rows {
+		line := []string{r.Target(), r.Activity}
+		for _, hours := range r.Hours {
+			line = append(line, formatTimesheetHours(hours))
+		}
+		lines = append(lines, line)
+	}
+
+	widths := make([]int, len(header))
+	for _, line := range lines {
+		for i, cell := range line {
+			if len(cell) > widths[i] {
+				widths[i] = len(cell)
+			}
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Timesheet for week %v (%v - %v)\n", s.Week,
+		s.From.Format(client.DayDateFormat), s.To.Format(client.DayDateFormat))
+	b.WriteString(`#
+# Change hours in cells ('1.5' or '1h30m'; '-' or empty for none), add rows for
+# issues ('i:<id|alias>') or projects ('p:<id|identifier|alias>'), or remove rows
+# to delete their time entries. Text after the last '|' is ignored.
+#
+`)
+	for n, line := range lines {
+		for i, cell := range line {
+			fmt.Fprintf(&b, "%-*s | ", widths[i], cell)
+		}
+		if n > 0 {
+			r := s.Rows[n-1]
+			b.WriteString(strings.TrimSpace(fmt.Sprintf("%v %v", r.Project, r.Subject)))
+		}
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// timesheetChange is a single time entry request needed to apply edited timesheet.
+type timesheetChange struct {
+	description string
+	apply       func() error
+}
+
+// diff parses edited timesheet and returns changes needed to apply it.
+func (s *timesheet) diff(text string, activities client.Activities) ([]timesheetChange, error) {
+	parsed, err := parseTimesheet(text, activities)
+	if err != nil {
+		return nil, err
+	}
+
+	added := make(map[string]*timesheetRow, len(parsed))
+	for _, row := range parsed {
+		added[row.key()] = row
+	}
+
+	var changes []timesheetChange
+	for _, row := range s.Rows {
+		var hours [timesheetDays]float64
+		if edited, found := added[row.key()]; found {
+			hours = edited.Hours
+			delete(added, row.key())
+		}
+
+		for day := 0; day < timesheetDays; day++ {
+			changes = append(changes, s.diffCell(row, day, hours[day])...)
+		}
+	}
+
+	// rows that are left are the new ones, applied in the order of lines
+	for _, row := range parsed {
+		if _, found := added[row.key()]; !found {
+			continue
+		}
+
+		for day := 0; day < timesheetDays; day++ {
+			if row.Hours[day] >= 0.005 {
+				changes = append(changes, s.addChange(row, day, row.Hours[day]))
+			}
+		}
+	}
+
+	return changes, nil
+}
+
+// parseTimesheet parses rows of timesheet text returned by editorText.
+func parseTimesheet(text string, activities client.Activities) ([]*timesheetRow, error) {
+	var rows []*timesheetRow
+	seen := make(map[string]bool)
+
+	for n, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		cells := strings.Split(line, "|")
+		if len(cells) < timesheetDays+2 {
+			return nil, fmt.Errorf("line %v: expected target, activity and %v days separated by '|'",
+				n+1, timesheetDays)
+		}
+
+		row, err := parseTimesheetTarget(strings.TrimSpace(cells[0]))
+		if err != nil {
+			return nil, fmt.Errorf("line %v: %v", n+1, err)
+		}
+
+		row.Activity = strings.TrimSpace(cells[1])
+		activityID, found := activities.Valid(row.Activity)
+		if !found {
+			return nil, fmt.Errorf("line %v: invalid activity '%v' (allowed ones: [%v])",
+				n+1, row.Activity, utils.PrintWithDelimiter(activities.Names()))
+		}
+		row.activityID = activityID
+
+		for day := 0; day < timesheetDays; day++ {
+			row.Hours[day], err = parseTimesheetHours(strings.TrimSpace(cells[day+2]))
+			if err != nil {
+				return nil, fmt.Errorf("line %v: %v", n+1, err)
+			}
+		}
+
+		if seen[row.key()] {
+			return nil, fmt.Errorf("line %v: duplicate row for %v (%v)", n+1, row.Target(), row.Activity)
+		}
+		seen[row.key()] = true
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// parseTimesheetTarget parses issue ('i:<id|alias>') or project ('p:<id|identifier|alias>') target.
+func parseTimesheetTarget(target string) (*timesheetRow, error) {
+	kind, value, found := strings.Cut(target, ":")
+	if !found || value == "" {
+		return nil, fmt.Errorf("invalid target '%v' (use 'i:<id>' or 'p:<id>')", target)
+	}
+
+	switch strings.ToLower(kind) {
+	case "i":
+		if alias, found := config.GetAlias(value); found {
+			value = alias
+		}
+
+		issueID, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid issue id '%v'", value)
+		}
+
+		return &timesheetRow{IssueID: issueID}, nil
+	case "p":
+		projectID, err := resolveProjectID(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid project '%v': %v", value, err)
+		}
+
+		return &timesheetRow{ProjectID: projectID}, nil
+	default:
+		return nil, fmt.Errorf("invalid target '%v' (use 'i:<id>' or 'p:<id>')", target)
+	}
+}
+
+// diffCell returns changes of a single cell. Time is added to or removed from the
+// last entries of the cell, so the other ones are kept untouched.
+func (s *timesheet) diffCell(row *timesheetRow, day int, hours float64) []timesheetChange {
+	delta := hours - row.Hours[day]
+	if math.Abs(delta) < 0.005 {
+		return nil
+	}
+
+	entries := row.entries[day]
+	if len(entries) == 0 {
+		return []timesheetChange{s.addChange(row, day, hours)}
+	}
+
+	if delta > 0 {
+		last := entries[len(entries)-1]
+		return []timesheetChange{updateEntryChange(last, last.Hours+delta)}
+	}
+
+	var changes []timesheetChange
+	remaining := -delta
+	for i := len(entries) - 1; i >= 0 && remaining >= 0.005; i-- {
+		entry := entries[i]
+		if entry.Hours > remaining+0.005 {
+			changes = append(changes, updateEntryChange(entry, entry.Hours-remaining))
+			break
+		}
+
+		changes = append(changes, deleteEntryChange(entry))
+		remaining -= entry.Hours
+	}
+
+	return changes
+}
+
+func (s *timesheet) addChange(row *timesheetRow, day int, hours float64) timesheetChange {
+	spentOn := s.day(day)
+	post := client.TimeEntryPost{
+		IssueID:    int(row.IssueID),
+		SpentOn:    *client.NewDateTime(spentOn),
+		Hours:      float32(hours),
+		ActivityID: int(row.activityID),
+	}
+	if row.IssueID == 0 {
+		post.ProjectID = int(row.ProjectID)
+	}
+
+	return timesheetChange{
+		description: fmt.Sprintf("add %vh to %v (%v) on %v", formatTimesheetHours(hours),
+			row.Target(), row.Activity, spentOn.Format(client.DayDateFormat)),
+		apply: func() error {
+			_, err := RClient.AddTimeEntry(ctx, post)
+			return err
+		},
+	}
+}
+
+func updateEntryChange(entry client.TimeEntry, hours float64) timesheetChange {
+	return timesheetChange{
+		description: fmt.Sprintf("update time entry %v on %v from %vh to %vh", entry.ID,
+			entry.SpentOn.Format(client.DayDateFormat), formatTimesheetHours(entry.Hours),
+			formatTimesheetHours(hours)),
+		apply: func() error {
+			return RClient.UpdateTimeEntry(ctx, int(entry.ID), client.TimeEntryPost{
+				SpentOn: entry.SpentOn,
+				Hours:   float32(hours),
+			})
+		},
+	}
+}
+
+func deleteEntryChange(entry client.TimeEntry) timesheetChange {
+	return timesheetChange{
+		description: fmt.Sprintf("delete time entry %v on %v (%vh)", entry.ID,
+			entry.SpentOn.Format(client.DayDateFormat), formatTimesheetHours(entry.Hours)),
+		apply: func() error {
+			return RClient.DeleteTimeEntry(ctx, int(entry.ID))
+		},
+	}
+}
@@ -5,8 +5,11 @@ import (
 	"log"
 	"os"
 	"path"
+	"strings"
+	"time"
 
 	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
 )
 
 const (
@@ -22,21 +25,75 @@ const (
 	UserID = "userID"
 	// CaCert is path to Redmine server SSL certificate
 	CaCert = "caCert"
+	// Profile is the key of the active profile name in config.
+	Profile = "profile"
+	// ProfilesMap is the key of the profiles map in config.
+	ProfilesMap = "profiles"
+	// TemplatesMap is the key of the named output templates map in config.
+	TemplatesMap = "templates"
 )
 
+// DefaultProfile is the name of the profile used when no other is selected.
+const DefaultProfile = "default"
+
+// ProfileEnv is the environment variable that selects active profile.
+const ProfileEnv = "ARCLI_PROFILE"
+
+// profileKeys are keys stored separately for every profile.
+var profileKeys = []string{Host, APIKey, UserID, CaCert, AliasesMap, DefaultsMap}
+
+var activeProfile = DefaultProfile
+
 // DefaultsKey represents default key.
 type DefaultsKey string
 
 const (
 	// Activity represents Redmine Activity.
 	Activity DefaultsKey = "activity"
+	// Rounding represents the increment (e.g. '15m') timer duration is rounded to.
+	Rounding DefaultsKey = "rounding"
+	// DailyTarget represents hours expected to be logged on working days (Monday to Friday).
+	DailyTarget DefaultsKey = "dailytarget"
+	// WeeklyTarget represents hours expected to be logged in a week, split evenly over
+	// working days if daily target is not set.
+	WeeklyTarget DefaultsKey = "weeklytarget"
+	// Holidays represents the path of ICS or YAML file with public holidays.
+	Holidays DefaultsKey = "holidays"
+	// DateOrder represents the order of month and day in short dates ('mdy' or 'dmy').
+	DateOrder DefaultsKey = "dateorder"
+	// SessionGap represents the longest pause between commits in the same work session.
+	SessionGap DefaultsKey = "sessiongap"
+	// SessionStart represents time spent before the first commit of work session.
+	SessionStart DefaultsKey = "sessionstart"
+	// HookMode represents what git hooks do with commits referencing closed or unknown
+	// issues ('fail' or 'warn').
+	HookMode DefaultsKey = "hookmode"
+	// BranchPattern represents regular expression matching issue ID in git branch names.
+	BranchPattern DefaultsKey = "branchpattern"
+	// MaxAttempts represents the number of attempts of requests that fail because
+	// Redmine server is temporarily unavailable.
+	MaxAttempts DefaultsKey = "maxattempts"
 )
 
+// WeekdayTarget returns the key of hours expected to be logged on given weekday,
+// which overrides daily and weekly target (e.g. 'fridaytarget').
+func WeekdayTarget(day time.Weekday) DefaultsKey {
+	return DefaultsKey(strings.ToLower(day.String()) + "target")
+}
+
 // AvailableDefaultsKeys stores all keys that are supported as defaults.
-var AvailableDefaultsKeys = []string{string(Activity)}
+var AvailableDefaultsKeys = []string{string(Activity), string(Rounding), string(DateOrder), string(Holidays),
+	string(SessionGap), string(SessionStart), string(HookMode),
+	string(BranchPattern), string(MaxAttempts), string(DailyTarget),
+	string(WeeklyTarget), string(WeekdayTarget(time.Monday)), string(WeekdayTarget(time.Tuesday)),
+	string(WeekdayTarget(time.Wednesday)), string(WeekdayTarget(time.Thursday)),
+	string(WeekdayTarget(time.Friday)), string(WeekdayTarget(time.Saturday)),
+	string(WeekdayTarget(time.Sunday))}
 
-// Setup setups permanent configuration in local storage.
-func Setup() {
+// Setup setups permanent configuration in local storage and selects active profile.
+// Profile given as parameter has precedence over ARCLI_PROFILE environment variable,
+// which has precedence over the profile saved in configuration.
+func Setup(profile string) {
 	home, err := os.UserHomeDir()
 	if err != nil {
 		fmt.Println(err)
@@ -58,19 +115,48 @@ func Setup() {
 	if err != nil {
 		log.Fatalln("Cannot read in configuration:", err)
 	}
+
+	err = migrateLegacyConfig()
+	if err != nil {
+		log.Fatalln("Cannot migrate configuration to profiles:", err)
+	}
+
+	switch {
+	case profile != "":
+		activeProfile = strings.ToLower(profile)
+	case os.Getenv(ProfileEnv) != "":
+		activeProfile = strings.ToLower(os.Getenv(ProfileEnv))
+	case viper.GetString(Profile) != "":
+		activeProfile = viper.GetString(Profile)
+	}
+
+	if activeProfile != DefaultProfile && !ProfileExists(activeProfile) {
+		fmt.Printf("Profile '%v' does not exist (add it with 'arcli profile add %v').\n",
+			activeProfile, activeProfile)
+		os.Exit(1)
+	}
+}
+
+// Key returns the config key of given profile key in the active profile.
+func Key(key string) string {
+	return profileKey(activeProfile, key)
+}
+
+func profileKey(profile, key string) string {
+	return strings.Join([]string{ProfilesMap, profile, key}, ".")
 }
 
 // Defaults lists all defaults saved to permanent configuration.
 func Defaults() map[string]string {
-	return viper.GetStringMapString(DefaultsMap)
+	return viper.GetStringMapString(Key(DefaultsMap))
 }
 
 // SetDefault sets default value for given key.
 func SetDefault(key DefaultsKey, value string) error {
-	defaults := viper.GetStringMapString(DefaultsMap)
+	defaults := Defaults()
 	defaults[string(key)] = value
 
-	viper.Set(DefaultsMap, defaults)
+	viper.Set(Key(DefaultsMap), defaults)
 
 	err := viper.WriteConfig()
 	if err != nil {
@@ -82,7 +168,7 @@ func SetDefault(key DefaultsKey, value string) error {
 
 // GetAliases gets all stored aliases from permanent configuration.
 func GetAliases() map[string]string {
-	return viper.GetStringMapString(AliasesMap)
+	return viper.GetStringMapString(Key(AliasesMap))
 }
 
 // GetAlias gets the alias from permanent configuration.
@@ -96,7 +182,7 @@ func GetAlias(key string) (value string, found bool) {
 func SetAlias(key string, value string) error {
 	aliases := GetAliases()
 	defer func() {
-		viper.Set(AliasesMap, aliases)
+		viper.Set(Key(AliasesMap), aliases)
 		err := viper.WriteConfig()
 		if err != nil {
 			panic("unable to write config while adding new alias")
@@ -111,3 +197,77 @@ func SetAlias(key string, value string) error {
 
 	return nil
 }
+
+// GetTemplates gets all named output templates from permanent configuration.
+// Templates are shared between profiles.
+func GetTemplates() map[string]string {
+	return viper.GetStringMapString(TemplatesMap)
+}
+
+// GetTemplate gets the named output template from permanent configuration.
+func GetTemplate(name string) (value string, found bool) {
+	value, found = GetTemplates()[strings.ToLower(name)]
+	return
+}
+
+// SetTemplate sets the named output template to permanent configuration.
+// Empty value removes the template.
+func SetTemplate(name string, value string) error {
+	templates := GetTemplates()
+	if value == "" {
+		delete(templates, strings.ToLower(name))
+	} else {
+		templates[strings.ToLower(name)] = value
+	}
+
+	viper.Set(TemplatesMap, templates)
+
+	err := viper.WriteConfig()
+	if err != nil {
+		return fmt.Errorf("unable to write config while saving template")
+	}
+
+	return nil
+}
+
+// migrateLegacyConfig moves credentials, aliases and defaults saved before
+// profiles were introduced to the default profile.
+func migrateLegacyConfig() error {
+	if viper.IsSet(ProfilesMap) {
+		return nil
+	}
+
+	settings := viper.AllSettings()
+	profile := make(map[string]interface{})
+	for _, key := range profileKeys {
+		key = strings.ToLower(key)
+		if value, found := settings[key]; found {
+			profile[key] = value
+			delete(settings, key)
+		}
+	}
+
+	if len(profile) == 0 {
+		return nil
+	}
+
+	settings[ProfilesMap] = map[string]interface{}{DefaultProfile: profile}
+
+	return rewriteConfig(settings)
+}
+
+// rewriteConfig replaces the whole configuration file with given settings. Unlike
+// viper.WriteConfig, it is able to remove keys.
+func rewriteConfig(settings map[string]interface{}) error {
+	data, err := yaml.Marshal(settings)
+	if err != nil {
+		return err
+	}
+
+	err = os.WriteFile(viper.ConfigFileUsed(), data, 0600)
+	if err != nil {
+		return err
+	}
+
+	return viper.ReadInConfig()
+}
@@ -0,0 +1,84 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// EntryTemplatesMap is the key of the time entry templates map in config.
+const EntryTemplatesMap = "entrytemplates"
+
+// EntryTemplate represents named set of time entry values. Empty values are
+// taken from defaults or given when template is used.
+type EntryTemplate struct {
+	Project  string `mapstructure:"project" json:"project,omitempty"`
+	Issue    string `mapstructure:"issue" json:"issue,omitempty"`
+	Activity string `mapstructure:"activity" json:"activity,omitempty"`
+	Hours    string `mapstructure:"hours" json:"hours,omitempty"`
+	Comment  string `mapstructure:"comment" json:"comment,omitempty"`
+}
+
+func (t EntryTemplate) values() map[string]string {
+	values := map[string]string{
+		"project":  t.Project,
+		"issue":    t.Issue,
+		"activity": t.Activity,
+		"hours":    t.Hours,
+		"comment":  t.Comment,
+	}
+	for key, value := range values {
+		if value == "" {
+			delete(values, key)
+		}
+	}
+
+	return values
+}
+
+// GetEntryTemplates gets all time entry templates of active profile from
+// permanent configuration.
+func GetEntryTemplates() map[string]EntryTemplate {
+	templates := make(map[string]EntryTemplate)
+	_ = viper.UnmarshalKey(Key(EntryTemplatesMap), &templates)
+	return templates
+}
+
+// GetEntryTemplate gets the time entry template from permanent configuration.
+func GetEntryTemplate(name string) (template EntryTemplate, found bool) {
+	template, found = GetEntryTemplates()[strings.ToLower(name)]
+	return
+}
+
+// SetEntryTemplate sets the time entry template to permanent configuration.
+func SetEntryTemplate(name string, template EntryTemplate) error {
+	templates := GetEntryTemplates()
+	templates[strings.ToLower(name)] = template
+
+	return setEntryTemplates(templates)
+}
+
+// RemoveEntryTemplate removes the time entry template from permanent configuration.
+func RemoveEntryTemplate(name string) error {
+	templates := GetEntryTemplates()
+	delete(templates, strings.ToLower(name))
+
+	return setEntryTemplates(templates)
+}
+
+func setEntryTemplates(templates map[string]EntryTemplate) error {
+	values := make(map[string]map[string]string, len(templates))
+	for name, template := range templates {
+		values[name] = template.values()
+	}
+
+	viper.Set(Key(EntryTemplatesMap), values)
+
+	err := viper.WriteConfig()
+	if err != nil {
+		return fmt.Errorf("unable to write config while saving time entry template")
+	}
+
+	return nil
+}
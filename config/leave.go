@@ -0,0 +1,47 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/spf13/viper"
+)
+
+// LeaveMap is the key of the personal leave days map in config.
+const LeaveMap = "leave"
+
+// GetLeaveDays gets personal leave days (as 'YYYY-MM-DD') with their notes from
+// permanent configuration.
+func GetLeaveDays() map[string]string {
+	return viper.GetStringMapString(Key(LeaveMap))
+}
+
+// AddLeaveDays adds personal leave days with the same note to permanent configuration.
+func AddLeaveDays(days []string, note string) error {
+	leave := GetLeaveDays()
+	for _, day := range days {
+		leave[day] = note
+	}
+
+	return setLeaveDays(leave)
+}
+
+// RemoveLeaveDays removes personal leave days from permanent configuration.
+func RemoveLeaveDays(days []string) error {
+	leave := GetLeaveDays()
+	for _, day := range days {
+		delete(leave, day)
+	}
+
+	return setLeaveDays(leave)
+}
+
+func setLeaveDays(leave map[string]string) error {
+	viper.Set(Key(LeaveMap), leave)
+
+	err := viper.WriteConfig()
+	if err != nil {
+		return fmt.Errorf("unable to write config while saving leave days")
+	}
+
+	return nil
+}
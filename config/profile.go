@@ -0,0 +1,94 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// ActiveProfile returns the name of currently used profile.
+func ActiveProfile() string {
+	return activeProfile
+}
+
+// Profiles returns sorted names of all stored profiles.
+func Profiles() []string {
+	profiles := viper.GetStringMap(ProfilesMap)
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// ProfileExists checks whether profile with given name is stored.
+func ProfileExists(name string) bool {
+	_, found := viper.GetStringMap(ProfilesMap)[strings.ToLower(name)]
+	return found
+}
+
+// ProfileValue returns the value of given key in the profile with provided name.
+func ProfileValue(name, key string) string {
+	return viper.GetString(profileKey(name, key))
+}
+
+// AddProfile stores a new profile with given server host and CA certificate path.
+func AddProfile(name, host, caCert string) error {
+	if ProfileExists(name) {
+		return fmt.Errorf("profile '%v' already exists", name)
+	}
+
+	viper.Set(profileKey(name, Host), host)
+	viper.Set(profileKey(name, CaCert), caCert)
+
+	err := viper.WriteConfig()
+	if err != nil {
+		return fmt.Errorf("unable to write config while adding new profile")
+	}
+
+	return nil
+}
+
+// UseProfile saves the profile with given name as active one.
+func UseProfile(name string) error {
+	if !ProfileExists(name) {
+		return fmt.Errorf("profile '%v' does not exist", name)
+	}
+
+	viper.Set(Profile, name)
+	activeProfile = name
+
+	err := viper.WriteConfig()
+	if err != nil {
+		return fmt.Errorf("unable to write config while switching profile")
+	}
+
+	return nil
+}
+
+// RemoveProfile removes the profile with given name together with its aliases
+// and defaults. If removed profile was saved as active, default one becomes active.
+func RemoveProfile(name string) error {
+	if !ProfileExists(name) {
+		return fmt.Errorf("profile '%v' does not exist", name)
+	}
+
+	settings := viper.AllSettings()
+	profiles, _ := settings[ProfilesMap].(map[string]interface{})
+	delete(profiles, strings.ToLower(name))
+
+	if strings.EqualFold(viper.GetString(Profile), name) {
+		delete(settings, Profile)
+	}
+
+	err := rewriteConfig(settings)
+	if err != nil {
+		return fmt.Errorf("unable to write config while removing profile: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+)
+
+// statePath returns the path of the file that keeps state with given name
+// (e.g. running timer) for the active profile.
+func statePath(name string) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	return path.Join(home, fmt.Sprintf(".arcli-%v-%v.json", name, activeProfile)), nil
+}
+
+// LoadState reads state with given name into v. It returns false if there is no
+// such state saved.
+func LoadState(name string, v interface{}) (bool, error) {
+	statePath, err := statePath(name)
+	if err != nil {
+		return false, err
+	}
+
+	data, err := os.ReadFile(statePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	err = json.Unmarshal(data, v)
+	if err != nil {
+		return false, fmt.Errorf("cannot read %v state: %v", name, err)
+	}
+
+	return true, nil
+}
+
+// SaveState saves state with given name, so it is kept between command runs.
+func SaveState(name string, v interface{}) error {
+	statePath, err := statePath(name)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(statePath, data, 0600)
+}
+
+// RemoveState removes state with given name.
+func RemoveState(name string) error {
+	statePath, err := statePath(name)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(statePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	return err
+}
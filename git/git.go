@@ -0,0 +1,177 @@
+// Package git reads commits, branches and configuration of git repositories
+// by running git command.
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// refsRegex matches Redmine issue references in commit messages (e.g. 'refs #20123').
+var refsRegex = regexp.MustCompile(`(?i)\b(?:refs|references|re|see|fixes|fixed|closes|closed)\s+#(\d+)`)
+
+// DefaultBranchPattern matches issue ID in branch names (e.g. 'feature/20123-foo').
+const DefaultBranchPattern = `(?:^|/)(\d+)(?:[-_.]|$)`
+
+// Commit represents git commit.
+type Commit struct {
+	Hash    string    `json:"hash"`
+	Time    time.Time `json:"time"`
+	Author  string    `json:"author"`
+	Email   string    `json:"email"`
+	Subject string    `json:"subject"`
+	Body    string    `json:"body"`
+}
+
+// LogOptions filter commits listed by Log.
+type LogOptions struct {
+	Since  time.Time
+	Until  time.Time
+	Author string
+	// Revisions are commits listed with their ancestors (all local branches if empty),
+	// e.g. []string{"feature/foo", "--not", "main"}.
+	Revisions []string
+}
+
+// Run runs git command in dir and returns its trimmed output.
+func Run(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	if err != nil {
+		message := strings.TrimSpace(stderr.String())
+		if message == "" {
+			message = err.Error()
+		}
+		return "", fmt.Errorf("git %v: %v", args[0], message)
+	}
+
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// Log lists commits (without merges) in repository in dir, ordered by time.
+func Log(dir string, options LogOptions) ([]Commit, error) {
+	args := []string{"log", "--no-merges", "--format=%H%x1f%aI%x1f%an%x1f%ae%x1f%s%x1f%b%x1e"}
+	if !options.Since.IsZero() {
+		args = append(args, "--since="+options.Since.Format(time.RFC3339))
+	}
+	if !options.Until.IsZero() {
+		args = append(args, "--until="+options.Until.Format(time.RFC3339))
+	}
+	if options.Author != "" {
+		args = append(args, "--author="+options.Author)
+	}
+
+	if len(options.Revisions) == 0 {
+		args = append(args, "--branches")
+	} else {
+		args = append(args, options.Revisions...)
+	}
+	// separates revisions from paths
+	args = append(args, "--")
+
+	output, err := Run(dir, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var commits []Commit
+	for _, record := range strings.Split(output, "\x1e") {
+		fields := strings.Split(strings.TrimLeft(record, "\n"), "\x1f")
+		if len(fields) != 6 {
+			continue
+		}
+
+		commitTime, err := time.Parse(time.RFC3339, fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid time of commit %v: %v", fields[0], err)
+		}
+
+		commits = append(commits, Commit{
+			Hash:    fields[0],
+			Time:    commitTime,
+			Author:  fields[2],
+			Email:   fields[3],
+			Subject: fields[4],
+			Body:    strings.TrimSpace(fields[5]),
+		})
+	}
+
+	sort.SliceStable(commits, func(i, j int) bool {
+		return commits[i].Time.Before(commits[j].Time)
+	})
+
+	return commits, nil
+}
+
+// Branches returns names of local branches in repository in dir.
+func Branches(dir string) ([]string, error) {
+	output, err := Run(dir, "for-each-ref", "--format=%(refname:short)", "refs/heads")
+	if err != nil {
+		return nil, err
+	}
+	if output == "" {
+		return nil, nil
+	}
+
+	return strings.Split(output, "\n"), nil
+}
+
+// CurrentBranch returns the name of branch checked out in repository in dir.
+func CurrentBranch(dir string) (string, error) {
+	return Run(dir, "symbolic-ref", "--short", "HEAD")
+}
+
+// HooksDir returns the absolute path of hooks directory of repository in dir.
+func HooksDir(dir string) (string, error) {
+	return Run(dir, "rev-parse", "--path-format=absolute", "--git-path", "hooks")
+}
+
+// UserEmail returns email of git user configured for repository in dir.
+func UserEmail(dir string) (string, error) {
+	return Run(dir, "config", "user.email")
+}
+
+// MessageIssues returns IDs of issues referenced in commit message (e.g. 'refs #20123').
+func MessageIssues(message string) []int64 {
+	var ids []int64
+	for _, match := range refsRegex.FindAllStringSubmatch(message, -1) {
+		id, err := strconv.ParseInt(match[1], 10, 64)
+		if err == nil {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids
+}
+
+// BranchIssue returns ID of issue in branch name matched by pattern, which is
+// the first submatch (or the whole match if there is no submatch).
+func BranchIssue(branch string, pattern *regexp.Regexp) (int64, bool) {
+	match := pattern.FindStringSubmatch(branch)
+	if match == nil {
+		return 0, false
+	}
+
+	value := match[0]
+	if len(match) > 1 {
+		value = match[1]
+	}
+
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return id, true
+}
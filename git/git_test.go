@@ -0,0 +1,76 @@
+package git
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func TestMessageIssues(t *testing.T) {
+	tests := []struct {
+		message string
+		want    []int64
+	}{
+		{"", nil},
+		{"Fix login", nil},
+		{"Fix login #20123", nil},
+		{"Fix login, refs #20123", []int64{20123}},
+		{"Fix login\n\nRefs #20123", []int64{20123}},
+		{"Fix login (references #7)", []int64{7}},
+		{"re #1, see #2", []int64{1, 2}},
+		{"Fixes #3 and closes #4", []int64{3, 4}},
+		{"fixed #5; Closed #6", []int64{5, 6}},
+		{"refs  #8", []int64{8}},
+		{"refs#9", nil},
+		{"prefs #10", nil},
+		{"refs #", nil},
+		{"refs #99999999999999999999", nil},
+	}
+
+	for _, tt := range tests {
+		if got := MessageIssues(tt.message); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("MessageIssues(%q) = %v, want %v", tt.message, got, tt.want)
+		}
+	}
+}
+
+func TestBranchIssue(t *testing.T) {
+	defaultPattern := regexp.MustCompile(DefaultBranchPattern)
+
+	tests := []struct {
+		branch  string
+		pattern *regexp.Regexp
+		want    int64
+		found   bool
+	}{
+		{"feature/1234-foo", defaultPattern, 1234, true},
+		{"feature/1234_foo", defaultPattern, 1234, true},
+		{"feature/1234.foo", defaultPattern, 1234, true},
+		{"feature/1234", defaultPattern, 1234, true},
+		{"1234", defaultPattern, 1234, true},
+		{"1234-foo", defaultPattern, 1234, true},
+		{"user/fix/1234-foo", defaultPattern, 1234, true},
+		{"feature/foo-1234", defaultPattern, 0, false},
+		{"feature/v2", defaultPattern, 0, false},
+		{"feature/1234foo", defaultPattern, 0, false},
+		{"main", defaultPattern, 0, false},
+		{"", defaultPattern, 0, false},
+
+		// submatch
+		{"PROJ-1234-foo", regexp.MustCompile(`^PROJ-(\d+)`), 1234, true},
+		{"feature/foo-1234", regexp.MustCompile(`-(\d+)$`), 1234, true},
+		// whole match without submatch
+		{"feature/foo-1234", regexp.MustCompile(`\d+$`), 1234, true},
+		// match which is not a number
+		{"feature/foo", regexp.MustCompile(`feature/(\w+)`), 0, false},
+		{"feature/99999999999999999999", regexp.MustCompile(`\d+`), 0, false},
+	}
+
+	for _, tt := range tests {
+		got, found := BranchIssue(tt.branch, tt.pattern)
+		if got != tt.want || found != tt.found {
+			t.Errorf("BranchIssue(%q, %v) = %v, %v, want %v, %v", tt.branch, tt.pattern, got, found,
+				tt.want, tt.found)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package parse
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DateFormat is the format of dates in Redmine.
+const DateFormat = "2006-01-02"
+
+// maxRangeDays is the maximum number of days in date range.
+const maxRangeDays = 366
+
+// DateOrder represents the order of month and day in short dates (e.g. '10/15').
+type DateOrder int
+
+const (
+	// MonthFirst order reads '10/15' as October 15.
+	MonthFirst DateOrder = iota
+	// DayFirst order reads '15/10' or '15.10.' as October 15.
+	DayFirst
+)
+
+// DateOrders stores names of all supported date orders.
+var DateOrders = []string{"mdy", "dmy"}
+
+// ParseDateOrder returns date order with given name ('mdy' or 'dmy'). Empty
+// name is month first order.
+func ParseDateOrder(name string) (DateOrder, error) {
+	switch strings.ToLower(name) {
+	case "", "mdy", "md":
+		return MonthFirst, nil
+	case "dmy", "dm":
+		return DayFirst, nil
+	}
+
+	return MonthFirst, fmt.Errorf("invalid date order '%v' (use 'mdy' or 'dmy')", name)
+}
+
+func (o DateOrder) example() string {
+	if o == DayFirst {
+		return "15/10"
+	}
+	return "10/15"
+}
+
+var (
+	relativeDateRegex = regexp.MustCompile(`^([+-]?)(\d+)\s*(d|days?|w|wks?|weeks?|mo|months?)(\s+ago)?$`)
+	shortDateRegex    = regexp.MustCompile(`^(\d{1,2})[/.](\d{1,2})(?:[/.](\d{4}|\d{2}))?\.?$`)
+)
+
+// DateParser parses dates relative to the current time. Dates are returned as
+// UTC midnight of the day.
+type DateParser struct {
+	Now   time.Time
+	Order DateOrder
+}
+
+// Date parses date given as:
+//   - 'today', 'yesterday' or 'tomorrow',
+//   - weekday name ('monday' or 'mon'), which is the last such day, today included,
+//   - 'last' and weekday name ('last friday'), which is the last such day before today,
+//   - number of days, weeks or months before or after today ('-3d', '+1w', '2w ago', '1 month ago'),
+//   - short date with month and day in configured order ('10/15', '10/15/2026', '15.10.'),
+//   - ISO date ('2026-10-15').
+func (p DateParser) Date(value string) (time.Time, error) {
+	today := time.Date(p.Now.Year(), p.Now.Month(), p.Now.Day(), 0, 0, 0, 0, time.UTC)
+	v := strings.Join(strings.Fields(strings.ToLower(value)), " ")
+
+	switch v {
+	case "today", "now":
+		return today, nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), nil
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), nil
+	}
+
+	if day, found := weekday(v); found {
+		return today.AddDate(0, 0, -daysSince(today.Weekday(), day)), nil
+	}
+	if name, found := strings.CutPrefix(v, "last "); found {
+		if day, found := weekday(name); found {
+			diff := daysSince(today.Weekday(), day)
+			if diff == 0 {
+				diff = 7
+			}
+			return today.AddDate(0, 0, -diff), nil
+		}
+	}
+
+	if m := relativeDateRegex.FindStringSubmatch(v); m != nil {
+		sign, ago := m[1], m[4] != ""
+		if (sign == "") == !ago {
+			return time.Time{}, p.invalid(value)
+		}
+
+		n, _ := strconv.Atoi(m[2])
+		if sign == "-" || ago {
+			n = -n
+		}
+
+		switch m[3][0] {
+		case 'd':
+			return today.AddDate(0, 0, n), nil
+		case 'w':
+			return today.AddDate(0, 0, 7*n), nil
+		default:
+			return today.AddDate(0, n, 0), nil
+		}
+	}
+
+	if m := shortDateRegex.FindStringSubmatch(v); m != nil {
+		month, _ := strconv.Atoi(m[1])
+		day, _ := strconv.Atoi(m[2])
+		if p.Order == DayFirst {
+			month, day = day, month
+		}
+
+		year := today.Year()
+		if m[3] != "" {
+			year, _ = strconv.Atoi(m[3])
+			if len(m[3]) == 2 {
+				year += 2000
+			}
+		}
+
+		date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+		if date.Month() != time.Month(month) || date.Day() != day {
+			return time.Time{}, fmt.Errorf("invalid date '%v'", value)
+		}
+		return date, nil
+	}
+
+	date, err := time.Parse(DateFormat, v)
+	if err != nil {
+		return time.Time{}, p.invalid(value)
+	}
+
+	return date, nil
+}
+
+// Range parses date or range of dates given as two dates separated by '..'
+// (e.g. 'mon..wed', '10/15..10/17', '-1w..today') and returns all days in range.
+// Weekday name at the end of range is the first such day after the start.
+func (p DateParser) Range(value string) ([]time.Time, error) {
+	first, last, found := strings.Cut(value, "..")
+	if !found {
+		date, err := p.Date(value)
+		if err != nil {
+			return nil, err
+		}
+		return []time.Time{date}, nil
+	}
+
+	from, err := p.Date(first)
+	if err != nil {
+		return nil, err
+	}
+
+	var to time.Time
+	if day, found := weekday(strings.ToLower(strings.TrimSpace(last))); found {
+		to = from.AddDate(0, 0, daysSince(day, from.Weekday()))
+	} else {
+		to, err = p.Date(last)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if to.Before(from) {
+		return nil, fmt.Errorf("the end of range '%v' is before its start", value)
+	}
+	if to.Sub(from).Hours()/24 >= maxRangeDays {
+		return nil, fmt.Errorf("range '%v' is longer than %v days", value, maxRangeDays)
+	}
+
+	var days []time.Time
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		days = append(days, day)
+	}
+
+	return days, nil
+}
+
+func (p DateParser) invalid(value string) error {
+	return fmt.Errorf("invalid date '%v' (use e.g. 'today', 'monday', 'last friday', '-3d', "+
+		"'2w ago', '%v' or '%v')", value, p.Order.example(), DateFormat)
+}
+
+// weekday returns weekday with given full or abbreviated name.
+func weekday(name string) (time.Weekday, bool) {
+	if len(name) < 3 {
+		return 0, false
+	}
+
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if strings.HasPrefix(strings.ToLower(day.String()), name) {
+			return day, true
+		}
+	}
+
+	return 0, false
+}
+
+// daysSince returns the number of days from the last weekday day to weekday today.
+func daysSince(today, day time.Weekday) int {
+	return (int(today) - int(day) + 7) % 7
+}
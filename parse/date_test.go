@@ -0,0 +1,95 @@
+package parse
+
+import (
+	"testing"
+	"time"
+)
+
+// now is Sunday.
+var now = time.Date(2026, time.October, 18, 23, 59, 59, 0, time.Local)
+
+func date(month time.Month, day int) time.Time {
+	return time.Date(2026, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestDate(t *testing.T) {
+	tests := []struct {
+		value string
+		order DateOrder
+		want  time.Time
+	}{
+		{"today", MonthFirst, date(time.October, 18)},
+		{"Yesterday", MonthFirst, date(time.October, 17)},
+		{"tomorrow", MonthFirst, date(time.October, 19)},
+		{"monday", MonthFirst, date(time.October, 12)},
+		{"fri", MonthFirst, date(time.October, 16)},
+		{"sunday", MonthFirst, date(time.October, 18)},
+		{"last friday", MonthFirst, date(time.October, 16)},
+		{"last sunday", MonthFirst, date(time.October, 11)},
+		{"-3d", MonthFirst, date(time.October, 15)},
+		{"+1d", MonthFirst, date(time.October, 19)},
+		{"2w ago", MonthFirst, date(time.October, 4)},
+		{"2 weeks ago", MonthFirst, date(time.October, 4)},
+		{"1 month ago", MonthFirst, date(time.September, 18)},
+		{"10/15", MonthFirst, date(time.October, 15)},
+		{"15/10", DayFirst, date(time.October, 15)},
+		{"15.10.", DayFirst, date(time.October, 15)},
+		{"10/15/25", MonthFirst, time.Date(2025, time.October, 15, 0, 0, 0, 0, time.UTC)},
+		{"2026-02-03", DayFirst, date(time.February, 3)},
+	}
+
+	for _, test := range tests {
+		got, err := DateParser{Now: now, Order: test.order}.Date(test.value)
+		if err != nil {
+			t.Errorf("Date(%q) returned error: %v", test.value, err)
+			continue
+		}
+		if !got.Equal(test.want) {
+			t.Errorf("Date(%q) = %v, want %v", test.value, got.Format(DateFormat), test.want.Format(DateFormat))
+		}
+	}
+}
+
+func TestDateInvalid(t *testing.T) {
+	for _, value := range []string{"", "someday", "last", "3d", "-3d ago", "13/01", "02/30", "2026-13-01"} {
+		if got, err := (DateParser{Now: now}).Date(value); err == nil {
+			t.Errorf("Date(%q) = %v, want error", value, got)
+		}
+	}
+}
+
+func TestRange(t *testing.T) {
+	tests := []struct {
+		value      string
+		from       time.Time
+		length     int
+		shouldFail bool
+	}{
+		{value: "today", from: date(time.October, 18), length: 1},
+		{value: "mon..wed", from: date(time.October, 12), length: 3},
+		{value: "fri..mon", from: date(time.October, 16), length: 4},
+		{value: "10/1..10/31", from: date(time.October, 1), length: 31},
+		{value: "-1w..today", from: date(time.October, 11), length: 8},
+		{value: "today..yesterday", shouldFail: true},
+		{value: "mon..", shouldFail: true},
+		{value: "2025-01-01..today", shouldFail: true},
+	}
+
+	for _, test := range tests {
+		got, err := DateParser{Now: now}.Range(test.value)
+		if test.shouldFail {
+			if err == nil {
+				t.Errorf("Range(%q) = %v, want error", test.value, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Range(%q) returned error: %v", test.value, err)
+			continue
+		}
+		if len(got) != test.length || !got[0].Equal(test.from) {
+			t.Errorf("Range(%q) = %v days from %v, want %v days from %v", test.value, len(got),
+				got[0].Format(DateFormat), test.length, test.from.Format(DateFormat))
+		}
+	}
+}
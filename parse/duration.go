@@ -0,0 +1,58 @@
+// Package parse reads human-friendly durations and dates given on command line.
+package parse
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Duration parses duration given as decimal hours ('1.5' or '1,5'), hours and
+// minutes ('1:30') or Go duration ('1h30m', '90m').
+func Duration(value string) (time.Duration, error) {
+	v := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(value), " ", ""))
+	if v == "" {
+		return 0, fmt.Errorf("empty duration")
+	}
+
+	var duration time.Duration
+	if h, m, found := strings.Cut(v, ":"); found {
+		hours, err := strconv.ParseUint(h, 10, 32)
+		if err != nil {
+			return 0, invalidDuration(value)
+		}
+		minutes, err := strconv.ParseUint(m, 10, 32)
+		if err != nil || len(m) != 2 || minutes > 59 {
+			return 0, invalidDuration(value)
+		}
+		duration = time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute
+	} else if hours, err := strconv.ParseFloat(strings.Replace(v, ",", ".", 1), 64); err == nil {
+		duration = time.Duration(hours * float64(time.Hour)).Round(time.Second)
+	} else {
+		duration, err = time.ParseDuration(v)
+		if err != nil {
+			return 0, invalidDuration(value)
+		}
+	}
+
+	if duration < 0 {
+		return 0, fmt.Errorf("negative duration '%v'", value)
+	}
+
+	return duration, nil
+}
+
+// Hours parses duration (see Duration) and returns it as a number of hours.
+func Hours(value string) (float64, error) {
+	duration, err := Duration(value)
+	if err != nil {
+		return 0, err
+	}
+
+	return duration.Hours(), nil
+}
+
+func invalidDuration(value string) error {
+	return fmt.Errorf("invalid duration '%v' (use e.g. '1.5', '1:30', '1h30m' or '90m')", value)
+}
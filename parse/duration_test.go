@@ -0,0 +1,53 @@
+package parse
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDuration(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"1.5", 90 * time.Minute},
+		{"1,5", 90 * time.Minute},
+		{"0.25", 15 * time.Minute},
+		{"2", 2 * time.Hour},
+		{"1:30", 90 * time.Minute},
+		{"0:05", 5 * time.Minute},
+		{"1h30m", 90 * time.Minute},
+		{"90m", 90 * time.Minute},
+		{" 1h 30m ", 90 * time.Minute},
+		{"2H", 2 * time.Hour},
+	}
+
+	for _, test := range tests {
+		got, err := Duration(test.value)
+		if err != nil {
+			t.Errorf("Duration(%q) returned error: %v", test.value, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("Duration(%q) = %v, want %v", test.value, got, test.want)
+		}
+	}
+}
+
+func TestDurationInvalid(t *testing.T) {
+	for _, value := range []string{"", "abc", "1:60", "1:5", ":30", "-1", "-1h", "1.5.5"} {
+		if got, err := Duration(value); err == nil {
+			t.Errorf("Duration(%q) = %v, want error", value, got)
+		}
+	}
+}
+
+func TestHours(t *testing.T) {
+	got, err := Hours("1:45")
+	if err != nil {
+		t.Fatalf("Hours returned error: %v", err)
+	}
+	if got != 1.75 {
+		t.Errorf("Hours(%q) = %v, want %v", "1:45", got, 1.75)
+	}
+}
@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// EditText opens text in the editor set by VISUAL or EDITOR environment variable
+// (vi if none is set) and returns the saved text. Pattern is used for the name of
+// temporary file, so the editor can recognize the content type (e.g. "*.md").
+func EditText(text, pattern string) (string, error) {
+	file, err := os.CreateTemp("", "arcli-"+pattern)
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(file.Name())
+
+	_, err = file.WriteString(text)
+	_ = file.Close()
+	if err != nil {
+		return "", err
+	}
+
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+
+	args := append(strings.Fields(editor), file.Name())
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	err = cmd.Run()
+	if err != nil {
+		return "", fmt.Errorf("editor '%v' failed: %v", editor, err)
+	}
+
+	edited, err := os.ReadFile(file.Name())
+	if err != nil {
+		return "", err
+	}
+
+	return string(edited), nil
+}
+
+// StripComments removes lines starting with '#' and trims surrounding whitespace.
+func StripComments(text string) string {
+	lines := strings.Split(text, "\n")
+	kept := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		kept = append(kept, line)
+	}
+
+	return strings.TrimSpace(strings.Join(kept, "\n"))
+}
@@ -0,0 +1,180 @@
+package utils
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/jedib0t/go-pretty/table"
+	"gopkg.in/yaml.v3"
+)
+
+// OutputFormat represents format in which command results are printed.
+type OutputFormat string
+
+const (
+	// OutputTable prints results as human-readable table.
+	OutputTable OutputFormat = "table"
+	// OutputJSON prints results as indented JSON.
+	OutputJSON OutputFormat = "json"
+	// OutputYAML prints results as YAML.
+	OutputYAML OutputFormat = "yaml"
+	// OutputCSV prints results as RFC 4180 comma-separated values.
+	OutputCSV OutputFormat = "csv"
+	// OutputTSV prints results as tab-separated values.
+	OutputTSV OutputFormat = "tsv"
+	// OutputMarkdown prints results as GitHub-flavoured markdown table.
+	OutputMarkdown OutputFormat = "markdown"
+)
+
+// OutputFormats stores all supported output formats.
+var OutputFormats = []string{string(OutputTable), string(OutputJSON), string(OutputYAML),
+	string(OutputCSV), string(OutputTSV), string(OutputMarkdown)}
+
+// ParseOutputFormat returns OutputFormat with given name.
+func ParseOutputFormat(name string) (OutputFormat, error) {
+	for _, format := range OutputFormats {
+		if name == format {
+			return OutputFormat(name), nil
+		}
+	}
+
+	return "", fmt.Errorf("invalid output format '%v' (allowed ones: [%v])",
+		name, PrintWithDelimiter(OutputFormats))
+}
+
+// Output renders command results in requested format.
+type Output struct {
+	Format   OutputFormat
+	Writer   io.Writer
+	Template *template.Template
+}
+
+// NewOutput returns Output that writes results in given format to standard output.
+func NewOutput(format OutputFormat) Output {
+	return Output{Format: format, Writer: os.Stdout}
+}
+
+// IsTable reports whether results are printed for humans rather than scripts.
+func (o Output) IsTable() bool {
+	return o.Format == "" || o.Format == OutputTable
+}
+
+// Render prints data in structured formats (JSON, YAML, template), or header with
+// rows in tabular ones (table, CSV, TSV, markdown). Data should be the original
+// client structs, so nothing is lost in structured formats.
+func (o Output) Render(data interface{}, header table.Row, rows []table.Row) error {
+	switch o.Format {
+	case OutputJSON:
+		return o.renderJSON(data)
+	case OutputYAML:
+		return o.renderYAML(data)
+	case OutputCSV:
+		return o.renderSeparated(',', header, rows)
+	case OutputTSV:
+		return o.renderSeparated('\t', header, rows)
+	case OutputMarkdown:
+		return o.renderMarkdown(header, rows)
+	case OutputTemplate:
+		return o.renderTemplate(data)
+	default:
+		t := NewTable()
+		t.SetOutputMirror(o.Writer)
+		t.AppendHeader(header)
+		t.AppendRows(rows)
+		t.Render()
+		return nil
+	}
+}
+
+func (o Output) renderJSON(data interface{}) error {
+	encoder := json.NewEncoder(o.Writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(data)
+}
+
+// renderYAML converts data to YAML through JSON, so the same field names and
+// value formats are used as in JSON output.
+func (o Output) renderYAML(data interface{}) error {
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	var generic interface{}
+	err = json.Unmarshal(jsonData, &generic)
+	if err != nil {
+		return err
+	}
+
+	encoder := yaml.NewEncoder(o.Writer)
+	encoder.SetIndent(2)
+	defer encoder.Close()
+
+	return encoder.Encode(generic)
+}
+
+func (o Output) renderSeparated(comma rune, header table.Row, rows []table.Row) error {
+	w := csv.NewWriter(o.Writer)
+	w.Comma = comma
+	w.UseCRLF = comma == ','
+
+	err := w.Write(rowStrings(header))
+	if err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		err = w.Write(rowStrings(row))
+		if err != nil {
+			return err
+		}
+	}
+
+	w.Flush()
+	return w.Error()
+}
+
+func (o Output) renderMarkdown(header table.Row, rows []table.Row) error {
+	var b strings.Builder
+
+	writeRow := func(cells []string) {
+		b.WriteString("|")
+		for _, cell := range cells {
+			b.WriteString(" " + markdownEscaper.Replace(cell) + " |")
+		}
+		b.WriteString("\n")
+	}
+
+	writeRow(rowStrings(header))
+	separator := make([]string, len(header))
+	for i := range separator {
+		separator[i] = "---"
+	}
+	writeRow(separator)
+	for _, row := range rows {
+		writeRow(rowStrings(row))
+	}
+
+	_, err := io.WriteString(o.Writer, b.String())
+	return err
+}
+
+var markdownEscaper = strings.NewReplacer("|", `\|`, "\r\n", "<br>", "\n", "<br>")
+
+func rowStrings(row table.Row) []string {
+	cells := make([]string, 0, len(row))
+	for _, cell := range row {
+		if cell == nil {
+			cells = append(cells, "")
+			continue
+		}
+		cells = append(cells, fmt.Sprint(cell))
+	}
+
+	return cells
+}
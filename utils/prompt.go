@@ -0,0 +1,41 @@
+package utils
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+var stdin = bufio.NewReader(os.Stdin)
+
+// Confirm asks user a yes/no question on standard input. Only 'y' and 'yes'
+// answers are considered as confirmation.
+func Confirm(question string) bool {
+	answer, err := Ask(question + " [y/N]: ")
+	if err != nil {
+		return false
+	}
+
+	switch strings.ToLower(answer) {
+	case "y", "yes":
+		return true
+	default:
+		return false
+	}
+}
+
+// Ask prints the prompt and returns trimmed line read from standard input.
+// Error (io.EOF if standard input is closed) is returned if there is no line to read.
+func Ask(prompt string) (string, error) {
+	fmt.Print(prompt)
+
+	line, err := stdin.ReadString('\n')
+	if err != nil && line == "" {
+		// prompt is not left unfinished
+		fmt.Println()
+		return "", err
+	}
+
+	return strings.TrimSpace(line), nil
+}
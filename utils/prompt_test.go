@@ -0,0 +1,39 @@
+package utils
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestAsk(t *testing.T) {
+	defer func(original *bufio.Reader) { stdin = original }(stdin)
+	stdin = bufio.NewReader(strings.NewReader("a\n\n  edit  \nlast"))
+
+	for _, want := range []string{"a", "", "edit", "last"} {
+		got, err := Ask("? ")
+		if err != nil || got != want {
+			t.Errorf("Ask() = %q, %v, want %q", got, err, want)
+		}
+	}
+
+	// closed input is reported every time
+	for i := 0; i < 2; i++ {
+		if got, err := Ask("? "); !errors.Is(err, io.EOF) {
+			t.Errorf("Ask() = %q, %v, want %v", got, err, io.EOF)
+		}
+	}
+}
+
+func TestConfirm(t *testing.T) {
+	defer func(original *bufio.Reader) { stdin = original }(stdin)
+	stdin = bufio.NewReader(strings.NewReader("y\nYes\nno\n\nmaybe\n"))
+
+	for _, want := range []bool{true, true, false, false, false, false} {
+		if got := Confirm("?"); got != want {
+			t.Errorf("Confirm() = %v, want %v", got, want)
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/jedib0t/go-pretty/text"
+)
+
+// OutputTemplate prints results using Go template executed for every result item.
+const OutputTemplate OutputFormat = "template"
+
+var templateColors = map[string]text.Color{
+	"black":   text.FgBlack,
+	"red":     text.FgRed,
+	"green":   text.FgGreen,
+	"yellow":  text.FgYellow,
+	"blue":    text.FgBlue,
+	"magenta": text.FgMagenta,
+	"cyan":    text.FgCyan,
+	"white":   text.FgWhite,
+	"bold":    text.Bold,
+	"italic":  text.Italic,
+}
+
+// TemplateFuncs returns helper functions available in output templates.
+func TemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"date":    templateDate,
+		"since":   templateSince,
+		"hours":   FormatHours,
+		"color":   templateColor,
+		"pad":     templatePad,
+		"padLeft": templatePadLeft,
+		"trunc":   templateTrunc,
+		"slug":    Slug,
+		"upper":   strings.ToUpper,
+		"lower":   strings.ToLower,
+		"trim":    strings.TrimSpace,
+		"replace": func(old, new, s string) string { return strings.ReplaceAll(s, old, new) },
+		"join":    func(sep string, s []string) string { return strings.Join(s, sep) },
+		"default": templateDefault,
+	}
+}
+
+// NewTemplateOutput returns Output that executes given Go template for every result item.
+// Additional functions are added to TemplateFuncs.
+func NewTemplateOutput(tmpl string, funcs template.FuncMap) (Output, error) {
+	t, err := template.New("output").Funcs(TemplateFuncs()).Funcs(funcs).Parse(tmpl)
+	if err != nil {
+		return Output{}, fmt.Errorf("invalid template: %v", err)
+	}
+
+	output := NewOutput(OutputTemplate)
+	output.Template = t
+
+	return output, nil
+}
+
+// renderTemplate executes template for every item if data is a slice, or once otherwise.
+// Each execution ends with a new line.
+func (o Output) renderTemplate(data interface{}) error {
+	items := []interface{}{data}
+
+	value := reflect.ValueOf(data)
+	if value.Kind() == reflect.Slice || value.Kind() == reflect.Array {
+		items = make([]interface{}, 0, value.Len())
+		for i := 0; i < value.Len(); i++ {
+			items = append(items, value.Index(i).Interface())
+		}
+	}
+
+	for _, item := range items {
+		var b strings.Builder
+		err := o.Template.Execute(&b, item)
+		if err != nil {
+			return err
+		}
+
+		result := b.String()
+		if !strings.HasSuffix(result, "\n") {
+			result += "\n"
+		}
+
+		_, err = fmt.Fprint(o.Writer, result)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// FormatHours formats decimal hours as duration (e.g. 1.5 as '1h30m').
+func FormatHours(hours float64) string {
+	minutes := int(math.Round(hours * 60))
+	switch {
+	case minutes%60 == 0:
+		return fmt.Sprintf("%dh", minutes/60)
+	case minutes < 60:
+		return fmt.Sprintf("%dm", minutes)
+	default:
+		return fmt.Sprintf("%dh%dm", minutes/60, minutes%60)
+	}
+}
+
+var slugInvalid = regexp.MustCompile("[^a-z0-9]+")
+
+// Slug converts text to lowercase words delimited with dashes (e.g. 'Managing users'
+// to 'managing-users'), suitable for branch or file names.
+func Slug(s string) string {
+	return strings.Trim(slugInvalid.ReplaceAllString(strings.ToLower(s), "-"), "-")
+}
+
+// asTime returns time.Time from time value, pointer to it or struct that embeds it
+// (e.g. client.DateTime). Second value is false for nil or zero times.
+func asTime(value interface{}) (time.Time, bool) {
+	v := reflect.ValueOf(value)
+	for v.IsValid() && v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return time.Time{}, false
+		}
+		v = v.Elem()
+	}
+
+	if !v.IsValid() || v.Kind() != reflect.Struct {
+		return time.Time{}, false
+	}
+
+	t, ok := v.Interface().(time.Time)
+	if !ok {
+		field := v.FieldByName("Time")
+		if !field.IsValid() || !field.CanInterface() {
+			return time.Time{}, false
+		}
+		t, ok = field.Interface().(time.Time)
+	}
+
+	return t, ok && !t.IsZero()
+}
+
+func templateDate(layout string, value interface{}) string {
+	t, ok := asTime(value)
+	if !ok {
+		return ""
+	}
+	return t.Format(layout)
+}
+
+func templateSince(value interface{}) string {
+	t, ok := asTime(value)
+	if !ok {
+		return ""
+	}
+	return FormatHours(time.Since(t).Truncate(time.Minute).Hours())
+}
+
+func templateColor(name string, value interface{}) (string, error) {
+	color, found := templateColors[name]
+	if !found {
+		return "", fmt.Errorf("unknown color '%v'", name)
+	}
+	return color.Sprint(value), nil
+}
+
+func templatePad(width int, value interface{}) string {
+	return fmt.Sprintf("%-*v", width, value)
+}
+
+func templatePadLeft(width int, value interface{}) string {
+	return fmt.Sprintf("%*v", width, value)
+}
+
+func templateTrunc(length int, value interface{}) string {
+	runes := []rune(fmt.Sprint(value))
+	if len(runes) <= length {
+		return string(runes)
+	}
+	return string(runes[:length])
+}
+
+func templateDefault(fallback, value interface{}) interface{} {
+	v := reflect.ValueOf(value)
+	if !v.IsValid() || v.IsZero() {
+		return fallback
+	}
+	return value
+}